@@ -0,0 +1,67 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Spec документ OpenAPI 3, отдаваемый на /openapi.json
+//
+//go:embed openapi.json
+var Spec []byte
+
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// VerifyRoutes сверяет зарегистрированные в gin маршруты с путями спецификации
+// и возвращает ошибку со списком расхождений в обе стороны
+func VerifyRoutes(routes gin.RoutesInfo) error {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(Spec, &doc); err != nil {
+		return fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+
+	documented := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			// Общие для пути поля (parameters, summary) не являются операциями
+			if !httpMethods[method] {
+				continue
+			}
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	var problems []string
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		key := route.Method + " " + ginParam.ReplaceAllString(route.Path, "{$1}")
+		registered[key] = true
+		if !documented[key] {
+			problems = append(problems, "not in spec: "+key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			problems = append(problems, "not registered: "+key)
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("routes do not match openapi spec: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
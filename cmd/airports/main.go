@@ -0,0 +1,77 @@
+// Команда airports загружает справочник аэропортов из CSV в формате OurAirports в таблицу airports.
+// Существующие записи обновляются по IATA коду, записи, отсутствующие в файле, не удаляются.
+package main
+
+import (
+	"context"
+	"flag"
+	"flight-service/internal/airports"
+	"flight-service/internal/app"
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/repository/airportRepo"
+	"log"
+	"os"
+	"time"
+	_ "time/tzdata" // часовые пояса проверяются без системной базы tzdata
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	file   = flag.String("file", "data/airports.csv", "path to the airports CSV")
+	dryRun = flag.Bool("dry-run", false, "validate the CSV without writing to the database")
+)
+
+func main() {
+	flag.Parse()
+
+	logger.Init(zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), zapcore.Lock(os.Stderr), zapcore.InfoLevel))
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open airports file: %v", err)
+	}
+	parsed, err := airports.ParseCSV(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("Failed to parse %s: %v", *file, err)
+	}
+
+	logger.Info("Airports parsed", zap.String("file", *file), zap.Int("count", len(parsed)))
+	if *dryRun {
+		return
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pool, err := app.OpenDB(ctx, cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	// Справочник загружается целиком или не загружается вовсе
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		log.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	written, err := airportRepo.NewAirportRepository(pool).WithTx(tx).Upsert(ctx, parsed)
+	if err != nil {
+		log.Fatalf("Failed to write airports: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Fatalf("Failed to commit airports: %v", err)
+	}
+
+	logger.Info("Airports loaded", zap.Int("written", written))
+}
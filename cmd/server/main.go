@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"flag"
 	"flight-service/internal/app"
 	"flight-service/internal/app/closer"
 	"flight-service/internal/config"
+	"flight-service/internal/grpcserver"
 	"flight-service/internal/kafka"
 	"flight-service/internal/logger"
 	"flight-service/internal/metrics"
@@ -12,31 +14,75 @@ import (
 	"go.uber.org/zap"
 	"log"
 	"net/http"
+	"os"
 	"time"
+	_ "time/tzdata" // образ scratch не содержит системной базы часовых поясов
+)
+
+var (
+	logLevel    = flag.String("log-level", "", "log level (debug, info, warn, error), overrides logger.level from config until it is changed in the file")
+	printConfig = flag.Bool("print-config", false, "print the effective config with secrets redacted and exit")
 )
 
 func main() {
+	flag.Parse()
+
 	ctx := context.Background()
 
+	if *logLevel != "" {
+		config.SetOverride("logger.level", *logLevel)
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	servers, err := app.SetupServer(ctx, cfg)
+	if *printConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			log.Fatalf("Failed to print config: %v", err)
+		}
+		return
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid config:\n%v", err)
+	}
+
+	cfgStore := config.NewStore(cfg)
+
+	servers, err := app.SetupServer(ctx, cfgStore)
 	if err != nil {
 		log.Fatalf("Failed to setup servers: %v", err)
 	}
 
+	cfgStore.Watch(ctx)
+
 	metrics.Register()
 
-	errChan := make(chan error, 2)
+	errChan := make(chan error, 4)
 
 	go runHTTPServer(servers.HTTP, "HTTP", errChan)
 	go runHTTPServer(servers.Prometheus, "Prometheus", errChan)
+	go runGRPCServer(servers.GRPC, "gRPC", errChan)
 
 	go runKafkaConsumer(ctx, servers.KafkaConsumer, "Kafka Consumer", errChan)
 
+	if servers.OutboxRelay != nil {
+		logger.Info("Starting outbox relay")
+		servers.OutboxRelay.Start()
+	}
+
+	if servers.Webhooks != nil {
+		logger.Info("Starting webhook dispatcher")
+		servers.Webhooks.Start()
+	}
+
+	if servers.Stream != nil {
+		logger.Info("Starting flight stream")
+		servers.Stream.Start()
+	}
+
 	closer.WaitForShutdown(ctx, errChan, servers)
 }
 
@@ -51,6 +97,17 @@ func runHTTPServer(s *http.Server, name string, errChan chan<- error) {
 	}
 }
 
+func runGRPCServer(s *grpcserver.Server, name string, errChan chan<- error) {
+	logger.Info("Starting server",
+		zap.String("name", name),
+		zap.String("address", s.Addr()),
+		zap.Time("started_at", time.Now()),
+	)
+	if err := s.ListenAndServe(); err != nil {
+		errChan <- fmt.Errorf("failed to start %s server: %w", name, err)
+	}
+}
+
 func runKafkaConsumer(ctx context.Context, consumer *kafka.Consumer, name string, errChan chan<- error) {
 	logger.Info("Starting Kafka consumer",
 		zap.String("name", name),
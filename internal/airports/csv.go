@@ -0,0 +1,139 @@
+package airports
+
+import (
+	"encoding/csv"
+	"errors"
+	"flight-service/internal/model"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Колонки CSV в формате OurAirports; timezone в OurAirports нет, она добавлена в файл сервиса
+const (
+	columnType      = "type"
+	columnName      = "name"
+	columnLatitude  = "latitude_deg"
+	columnLongitude = "longitude_deg"
+	columnCountry   = "iso_country"
+	columnCity      = "municipality"
+	columnIATA      = "iata_code"
+	columnTimezone  = "timezone"
+)
+
+var requiredColumns = []string{columnName, columnLatitude, columnLongitude, columnCountry, columnCity, columnIATA, columnTimezone}
+
+var (
+	iataPattern    = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// ParseCSV читает справочник аэропортов. Строки без IATA кода и закрытые аэропорты пропускаются,
+// ошибка в любой другой строке прерывает разбор с указанием номера строки.
+func ParseCSV(r io.Reader) ([]*model.Airport, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("csv column %q is missing", name)
+		}
+	}
+
+	var airports []*model.Airport
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		// FieldPos допустим только после успешного чтения, номер строки ошибки берем из ParseError
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("line %d: %w", parseErr.Line, parseErr.Err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			return strings.TrimSpace(record[index[name]])
+		}
+
+		code := field(columnIATA)
+		if code == "" {
+			continue
+		}
+		if i, ok := index[columnType]; ok && strings.TrimSpace(record[i]) == "closed" {
+			continue
+		}
+
+		airport, err := parseAirport(code, field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[code]; ok {
+			return nil, fmt.Errorf("line %d: airport %s is already defined on line %d", line, code, prev)
+		}
+		seen[code] = line
+
+		airports = append(airports, airport)
+	}
+
+	return airports, nil
+}
+
+func parseAirport(code string, field func(string) string) (*model.Airport, error) {
+	if !iataPattern.MatchString(code) {
+		return nil, fmt.Errorf("invalid iata_code %q", code)
+	}
+
+	name := field(columnName)
+	if name == "" {
+		return nil, fmt.Errorf("airport %s: name is empty", code)
+	}
+
+	country := field(columnCountry)
+	if !countryPattern.MatchString(country) {
+		return nil, fmt.Errorf("airport %s: invalid iso_country %q", code, country)
+	}
+
+	timezone := field(columnTimezone)
+	if timezone == "" {
+		return nil, fmt.Errorf("airport %s: timezone is empty", code)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("airport %s: unknown timezone %q", code, timezone)
+	}
+
+	latitude, err := strconv.ParseFloat(field(columnLatitude), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return nil, fmt.Errorf("airport %s: invalid latitude_deg %q", code, field(columnLatitude))
+	}
+	longitude, err := strconv.ParseFloat(field(columnLongitude), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("airport %s: invalid longitude_deg %q", code, field(columnLongitude))
+	}
+
+	return &model.Airport{
+		Code:      code,
+		Name:      name,
+		City:      field(columnCity),
+		Country:   country,
+		Timezone:  timezone,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package airports
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const testHeader = `"ident","type","name","latitude_deg","longitude_deg","iso_country","municipality","iata_code","timezone"` + "\n"
+
+const (
+	sheremetyevo = `"UUEE","large_airport","Sheremetyevo International Airport",55.972599,37.4146,"RU","Moscow","SVO","Europe/Moscow"` + "\n"
+	pulkovo      = `"ULLI","large_airport","Pulkovo Airport",59.8003,30.262501,"RU","Saint Petersburg","LED","Europe/Moscow"` + "\n"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantCodes []string
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			input:     testHeader + sheremetyevo + pulkovo,
+			wantCodes: []string{"SVO", "LED"},
+		},
+		{
+			name:      "closed airport and missing iata code are skipped",
+			input:     testHeader + sheremetyevo + `"XXXX","closed","Old Airport",55.0,37.0,"RU","Moscow","OLD","Europe/Moscow"` + "\n" + `"ZZZZ","heliport","Helipad",55.0,37.0,"RU","Moscow","","Europe/Moscow"` + "\n",
+			wantCodes: []string{"SVO"},
+		},
+		{
+			name:    "bare quote",
+			input:   testHeader + sheremetyevo + `"UUEE"x,"large_airport","Sheremetyevo",55.9,37.4,"RU","Moscow","SVO","Europe/Moscow"` + "\n",
+			wantErr: "line 3: extraneous",
+		},
+		{
+			name:    "unterminated quote",
+			input:   testHeader + `"UUEE,"large_airport","Sheremetyevo",55.9,37.4,"RU","Moscow","SVO","Europe/Moscow"` + "\n",
+			wantErr: "line 2: extraneous or missing \" in quoted-field",
+		},
+		{
+			name:    "missing column in row",
+			input:   testHeader + sheremetyevo + `"ULLI","large_airport","Pulkovo Airport",59.8,30.2,"RU","Saint Petersburg","LED"` + "\n",
+			wantErr: "line 3: wrong number of fields",
+		},
+		{
+			name:    "missing column in header",
+			input:   `"ident","type","name","latitude_deg","longitude_deg","iso_country","municipality","iata_code"` + "\n",
+			wantErr: `csv column "timezone" is missing`,
+		},
+		{
+			name:    "duplicate code",
+			input:   testHeader + sheremetyevo + pulkovo + sheremetyevo,
+			wantErr: "line 4: airport SVO is already defined on line 2",
+		},
+		{
+			name:    "bad timezone",
+			input:   testHeader + `"UUEE","large_airport","Sheremetyevo",55.9,37.4,"RU","Moscow","SVO","Europe/Mordor"` + "\n",
+			wantErr: `line 2: airport SVO: unknown timezone "Europe/Mordor"`,
+		},
+		{
+			name:    "invalid latitude",
+			input:   testHeader + `"UUEE","large_airport","Sheremetyevo",95.0,37.4,"RU","Moscow","SVO","Europe/Moscow"` + "\n",
+			wantErr: `line 2: airport SVO: invalid latitude_deg "95.0"`,
+		},
+		{
+			name:    "invalid iata code",
+			input:   testHeader + `"UUEE","large_airport","Sheremetyevo",55.9,37.4,"RU","Moscow","svo","Europe/Moscow"` + "\n",
+			wantErr: `line 2: invalid iata_code "svo"`,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: "failed to read csv header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			airports, err := ParseCSV(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseCSV() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCSV() error = %v", err)
+			}
+
+			codes := make([]string, len(airports))
+			for i, airport := range airports {
+				codes[i] = airport.Code
+			}
+			if strings.Join(codes, ",") != strings.Join(tt.wantCodes, ",") {
+				t.Errorf("codes = %v, want %v", codes, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestParseCSVAirport(t *testing.T) {
+	airports, err := ParseCSV(strings.NewReader(testHeader + pulkovo))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	if len(airports) != 1 {
+		t.Fatalf("airports = %d, want 1", len(airports))
+	}
+
+	got := airports[0]
+	if got.Code != "LED" || got.Name != "Pulkovo Airport" || got.City != "Saint Petersburg" || got.Country != "RU" ||
+		got.Timezone != "Europe/Moscow" || got.Latitude != 59.8003 || got.Longitude != 30.262501 {
+		t.Errorf("airport = %+v", got)
+	}
+}
+
+func TestParseCSVDataFile(t *testing.T) {
+	// Справочник из репозитория должен загружаться без ошибок
+	file, err := os.Open("../../data/airports.csv")
+	if err != nil {
+		t.Fatalf("failed to open data file: %v", err)
+	}
+	defer file.Close()
+
+	airports, err := ParseCSV(file)
+	if err != nil {
+		t.Fatalf("ParseCSV(data/airports.csv) error = %v", err)
+	}
+	if len(airports) == 0 {
+		t.Fatal("data/airports.csv has no airports")
+	}
+}
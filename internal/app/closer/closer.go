@@ -31,12 +31,23 @@ func WaitForShutdown(ctx context.Context, errChan <-chan error, s *app.Servers)
 
 	logger.Info("Starting graceful shutdown...")
 
+	// SSE запросы не завершаются сами, поэтому поток закрывается до остановки HTTP сервера
+	if s.Stream != nil {
+		logger.Info("Closing flight stream...")
+		s.Stream.Close()
+	}
+
 	// 1. Останавливаем прием новых HTTP запросов
 	logger.Info("Stopping HTTP server...")
 	if err := s.HTTP.Shutdown(shutdownCtx); err != nil {
 		logger.Error("HTTP server shutdown error:", zap.Error(err))
 	}
 
+	logger.Info("Stopping gRPC server...")
+	if err := s.GRPC.Shutdown(shutdownCtx); err != nil {
+		logger.Error("gRPC server shutdown error:", zap.Error(err))
+	}
+
 	// 2. Закрываем Kafka consumer
 	logger.Info("Closing Kafka consumer...")
 	if s.KafkaConsumer != nil {
@@ -45,6 +56,18 @@ func WaitForShutdown(ctx context.Context, errChan <-chan error, s *app.Servers)
 		}
 	}
 
+	if s.Webhooks != nil {
+		logger.Info("Stopping webhook dispatcher...")
+		s.Webhooks.Close()
+	}
+
+	if s.OutboxRelay != nil {
+		logger.Info("Stopping outbox relay...")
+		if err := s.OutboxRelay.Close(); err != nil {
+			logger.Error("Outbox relay close error:", zap.Error(err))
+		}
+	}
+
 	// 3. Закрываем Kafka producer (он завершит обработку сообщений в канале)
 	logger.Info("Closing Kafka producer (waiting for pending messages)...")
 	if s.KafkaProducer != nil {
@@ -58,8 +81,16 @@ func WaitForShutdown(ctx context.Context, errChan <-chan error, s *app.Servers)
 		logger.Error("Prometheus shutdown error:", zap.Error(err))
 	}
 
+	if s.Redis != nil {
+		logger.Info("Closing Redis connection...")
+		if err := s.Redis.Close(); err != nil {
+			logger.Error("Redis close error:", zap.Error(err))
+		}
+	}
+
 	logger.Info("Closing database connections...")
 	s.DB.Close()
 
 	logger.Info("Graceful shutdown completed")
+	_ = logger.Sync()
 }
@@ -2,71 +2,104 @@ package app
 
 import (
 	"context"
-	"flag"
+	"flight-service/internal/auth"
 	"flight-service/internal/config"
+	"flight-service/internal/grpcserver"
 	"flight-service/internal/handlers"
 	"flight-service/internal/handlers/routes"
 	"flight-service/internal/kafka"
 	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"flight-service/internal/ratelimit"
+	"flight-service/internal/repository"
+	"flight-service/internal/repository/airportRepo"
+	"flight-service/internal/repository/apiKeyRepo"
 	"flight-service/internal/repository/flightRepo"
+	"flight-service/internal/repository/historyRepo"
+	"flight-service/internal/repository/inboxRepo"
 	"flight-service/internal/repository/metaRepo"
+	"flight-service/internal/repository/offsetRepo"
+	"flight-service/internal/repository/outboxRepo"
+	"flight-service/internal/repository/spillRepo"
+	"flight-service/internal/repository/statusRepo"
+	"flight-service/internal/repository/streamRepo"
+	"flight-service/internal/repository/webhookRepo"
 	"flight-service/internal/service"
 	"flight-service/internal/service/flight"
+	"flight-service/internal/service/stream"
+	"flight-service/internal/service/webhook"
 	"fmt"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
-	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 )
 
-var logLevel = flag.String("1", "info", "log level")
-
 type Servers struct {
 	HTTP          *http.Server
 	Prometheus    *http.Server
+	GRPC          *grpcserver.Server
 	DB            *pgxpool.Pool
 	KafkaProducer *kafka.Producer
-	KafkaConsumer *kafka.Consumer // Добавляем consumer
+	KafkaConsumer *kafka.Consumer     // Добавляем consumer
+	OutboxRelay   *kafka.OutboxRelay  // nil, если события flight.updated выключены
+	Webhooks      *webhook.Dispatcher // nil, если вебхуки выключены
+	Stream        *stream.Broker      // nil, если SSE поток выключен
+	Redis         *redis.Client       // nil, если лимиты хранятся в памяти
+	LogLevel      zap.AtomicLevel
 }
 
-func SetupServer(ctx context.Context, cfg *config.Config) (*Servers, error) {
-	logger.Init(getCore(getAtomicLevel()))
+func SetupServer(ctx context.Context, cfgStore *config.Store) (*Servers, error) {
+	cfg := cfgStore.Current()
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Name,
-	)
+	logLevel, err := getAtomicLevel(cfg.Logger.Level)
+	if err != nil {
+		return nil, err
+	}
+	logger.Init(getCore(cfg.Logger, logLevel))
+
+	queryTracer := repository.NewQueryTracer(cfg.Database.SlowQueryThreshold)
 
-	pool, err := initDB(ctx, dsn)
+	pool, err := initDB(ctx, cfg.Database, queryTracer)
 	if err != nil {
 		logger.Error("Failed to connect to database", zap.Error(err))
 		return nil, err
 	}
 
+	if err := prometheus.Register(metrics.NewDBPoolCollector(pool)); err != nil {
+		logger.Error("Failed to register database pool metrics", zap.Error(err))
+	}
+
 	// Создаем Kafka producer
-	kafkaProducer, err := kafka.NewProducer(cfg.Kafka.KafkaBrokers, cfg.Kafka.Topic)
+	kafkaProducer, err := kafka.NewProducer(cfg.Kafka.KafkaBrokers, cfg.Kafka.Topic, cfg.Kafka.Producer, pool,
+		spillRepo.NewSpillRepository(pool), metaRepo.NewMetaRepository(pool))
 	if err != nil {
 		logger.Error("Failed to create Kafka producer", zap.Error(err))
 		return nil, err
 	}
 
-	flightService := createFlightService(kafkaProducer, pool)
+	flightService := createFlightService(kafkaProducer, pool, cfgStore)
 
-	initHandler := handlers.NewFlightHandler(flightService)
+	initHandler := handlers.NewFlightHandler(flightService, cfgStore)
 
 	kafkaConsumer, err := kafka.NewConsumer(
 		cfg.Kafka.KafkaBrokers,
 		cfg.Kafka.GroupID,
 		cfg.Kafka.Topic,
 		initHandler,
+		cfg.Kafka.Consumer.RetryAttempts,
+		cfg.Kafka.Consumer.RetryDelay,
+		transactionalID(cfg.Kafka.Consumer),
 	)
 
 	if err != nil {
@@ -74,13 +107,52 @@ func SetupServer(ctx context.Context, cfg *config.Config) (*Servers, error) {
 		return nil, err
 	}
 
-	ginEng := routes.SetupRoutes(initHandler)
+	subscribeReloadable(cfgStore, logLevel, kafkaConsumer, queryTracer)
+
+	var outboxRelay *kafka.OutboxRelay
+	if cfg.Kafka.Events.Enabled {
+		outboxRelay, err = kafka.NewOutboxRelay(cfg.Kafka.KafkaBrokers, cfg.Kafka.Events, pool, outboxRepo.NewOutboxRepository(pool))
+		if err != nil {
+			logger.Error("Failed to create outbox relay", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	authenticator, err := auth.NewAuthenticator(cfg.Auth, apiKeyRepo.NewAPIKeyRepository(pool))
+	if err != nil {
+		logger.Error("Failed to create authenticator", zap.Error(err))
+		return nil, err
+	}
+
+	limiter, redisClient, err := initRateLimiter(ctx, cfg)
+	if err != nil {
+		logger.Error("Failed to create rate limiter", zap.Error(err))
+		return nil, err
+	}
+
+	webhookRepository := webhookRepo.NewWebhookRepository(pool)
+	var dispatcher *webhook.Dispatcher
+	if cfg.Webhooks.Enabled {
+		dispatcher = webhook.NewDispatcher(webhookRepository, cfg.Webhooks)
+	}
+
+	// Интерфейс заполняется только при включенном потоке, чтобы обработчик видел nil, а не nil-указатель
+	var broker *stream.Broker
+	var streamService service.FlightStreamService
+	if cfg.Stream.Enabled {
+		broker = stream.NewBroker(pool, streamRepo.NewStreamRepository(pool), cfg.Stream)
+		streamService = broker
+	}
+
+	ginEng := routes.SetupRoutes(initHandler, handlers.NewWebhookHandler(webhook.NewWebhookService(webhookRepository, cfg.Webhooks)),
+		handlers.NewStreamHandler(streamService, cfgStore), handlers.NewAdminHandler(logLevel, cfgStore), authenticator, limiter, cfgStore)
 
 	return &Servers{
 		HTTP: &http.Server{
 			Addr:    cfg.Server.Port,
 			Handler: ginEng,
 		},
+		GRPC: grpcserver.NewServer(cfg.GRPC.Port, flightService, cfgStore, authenticator, limiter),
 		Prometheus: &http.Server{
 			Addr:        ":9000",
 			Handler:     promhttp.Handler(),
@@ -89,19 +161,15 @@ func SetupServer(ctx context.Context, cfg *config.Config) (*Servers, error) {
 		DB:            pool,
 		KafkaProducer: kafkaProducer,
 		KafkaConsumer: kafkaConsumer,
+		OutboxRelay:   outboxRelay,
+		Webhooks:      dispatcher,
+		Stream:        broker,
+		Redis:         redisClient,
+		LogLevel:      logLevel,
 	}, nil
 }
 
-func getCore(level zap.AtomicLevel) zapcore.Core {
-	stdout := zapcore.AddSync(os.Stdout)
-
-	file := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   "logs/app.log",
-		MaxSize:    10,
-		MaxBackups: 3,
-		MaxAge:     7,
-	})
-
+func getCore(cfg config.LoggerConfig, level zap.AtomicLevel) zapcore.Core {
 	productionCfg := zap.NewProductionEncoderConfig()
 	productionCfg.TimeKey = "timestamp"
 	productionCfg.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -109,25 +177,56 @@ func getCore(level zap.AtomicLevel) zapcore.Core {
 	developmentCfg := zap.NewDevelopmentEncoderConfig()
 	developmentCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
 
-	consoleEncoder := zapcore.NewConsoleEncoder(developmentCfg)
-	fileEncoder := zapcore.NewJSONEncoder(productionCfg)
+	var cores []zapcore.Core
 
-	return zapcore.NewTee(
-		zapcore.NewCore(consoleEncoder, stdout, level),
-		zapcore.NewCore(fileEncoder, file, level),
-	)
+	if cfg.Console {
+		consoleEncoder := zapcore.NewConsoleEncoder(developmentCfg)
+		if cfg.Format == "json" {
+			consoleEncoder = zapcore.NewJSONEncoder(productionCfg)
+		}
+		cores = append(cores, zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), level))
+	}
+
+	if cfg.File.Enabled {
+		file := zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSize,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAge:     cfg.File.MaxAge,
+			Compress:   cfg.File.Compress,
+		})
+		cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(productionCfg), file, level))
+	}
+
+	return zapcore.NewTee(cores...)
 }
 
-func getAtomicLevel() zap.AtomicLevel {
+func getAtomicLevel(levelName string) (zap.AtomicLevel, error) {
 	var level zapcore.Level
-	if err := level.Set(*logLevel); err != nil {
-		log.Fatalf("failed to set log level: %v", err)
+	if err := level.Set(levelName); err != nil {
+		return zap.AtomicLevel{}, fmt.Errorf("failed to set log level: %w", err)
 	}
-	return zap.NewAtomicLevelAt(level)
+	return zap.NewAtomicLevelAt(level), nil
 }
 
-func initDB(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(ctx, dsn)
+// OpenDB подключается к Postgres по конфигу без трассировки запросов, для вспомогательных команд
+func OpenDB(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	return initDB(ctx, cfg, nil)
+}
+
+func initDB(ctx context.Context, cfg config.DatabaseConfig, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(buildDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolCfg.ConnConfig.Tracer = tracer
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -141,9 +240,102 @@ func initDB(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-func createFlightService(kafkaProducer *kafka.Producer, dbPool *pgxpool.Pool) service.FlightService {
+// buildDSN собирает строку подключения к Postgres, включая параметры TLS
+func buildDSN(cfg config.DatabaseConfig) string {
+	params := url.Values{}
+	params.Set("sslmode", cfg.SSLMode)
+	if cfg.SSLRootCert != "" {
+		params.Set("sslrootcert", cfg.SSLRootCert)
+	}
+	if cfg.SSLCert != "" {
+		params.Set("sslcert", cfg.SSLCert)
+		params.Set("sslkey", cfg.SSLKey)
+	}
+	if cfg.ApplicationName != "" {
+		params.Set("application_name", cfg.ApplicationName)
+	}
+	if cfg.StatementTimeout > 0 {
+		params.Set("statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, cfg.Port),
+		Path:     cfg.Name,
+		RawQuery: params.Encode(),
+	}
+
+	return dsn.String()
+}
+
+// initRateLimiter создает хранилище лимитов. Бэкенд redis нужен, когда запущено несколько реплик сервиса.
+func initRateLimiter(ctx context.Context, cfg *config.Config) (ratelimit.Limiter, *redis.Client, error) {
+	if cfg.RateLimit.Backend != "redis" {
+		return ratelimit.NewMemoryLimiter(), nil, nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     net.JoinHostPort(cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return ratelimit.NewRedisLimiter(client), client, nil
+}
+
+// transactionalID идентификатор транзакционного producer consumer-а. Он должен быть уникален
+// для каждой реплики, поэтому по умолчанию строится из имени хоста.
+func transactionalID(cfg config.KafkaConsumerConfig) string {
+	if !cfg.Transactional {
+		return ""
+	}
+	if cfg.TransactionalID != "" {
+		return cfg.TransactionalID
+	}
+	hostname, _ := os.Hostname()
+	return "flight-service-" + hostname
+}
+
+func createFlightService(kafkaProducer *kafka.Producer, dbPool *pgxpool.Pool, cfgStore *config.Store) service.FlightService {
 	return flight.NewFlightService(metaRepo.NewMetaRepository(dbPool),
 		flightRepo.NewFlightRepository(dbPool),
+		historyRepo.NewHistoryRepository(dbPool),
+		statusRepo.NewStatusRepository(dbPool),
+		airportRepo.NewAirportRepository(dbPool),
+		offsetRepo.NewOffsetRepository(dbPool),
+		inboxRepo.NewInboxRepository(dbPool),
+		outboxRepo.NewOutboxRepository(dbPool),
+		webhookRepo.NewWebhookRepository(dbPool),
+		streamRepo.NewStreamRepository(dbPool),
 		kafkaProducer,
-		dbPool)
+		dbPool,
+		cfgStore)
+}
+
+// subscribeReloadable применяет перезагружаемые параметры конфига к уже запущенным компонентам
+func subscribeReloadable(cfgStore *config.Store, logLevel zap.AtomicLevel, consumer *kafka.Consumer, queryTracer *repository.QueryTracer) {
+	cfgStore.Subscribe(func(old, next *config.Config) {
+		if old.Logger.Level != next.Logger.Level {
+			level, err := zapcore.ParseLevel(next.Logger.Level)
+			if err != nil {
+				logger.Error("Failed to apply log level", zap.Error(err))
+			} else {
+				logLevel.SetLevel(level)
+			}
+		}
+
+		if old.Database.SlowQueryThreshold != next.Database.SlowQueryThreshold {
+			queryTracer.SetSlowThreshold(next.Database.SlowQueryThreshold)
+		}
+
+		if old.Kafka.Consumer != next.Kafka.Consumer {
+			consumer.SetRetryPolicy(next.Kafka.Consumer.RetryAttempts, next.Kafka.Consumer.RetryDelay)
+		}
+	})
 }
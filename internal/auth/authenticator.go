@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"flight-service/internal/repository"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// Authenticator проверяет API ключи и JWT и сопоставляет их области доступа
+type Authenticator struct {
+	enabled   bool
+	apiKeys   map[string]*Principal // по sha256 ключа
+	keyRepo   repository.APIKeyRepository
+	jwks      map[string]crypto.PublicKey
+	jwtParser *jwt.Parser
+}
+
+// NewAuthenticator создает Authenticator. keyRepo может быть nil, если ключи хранятся только в конфиге.
+func NewAuthenticator(cfg config.AuthConfig, keyRepo repository.APIKeyRepository) (*Authenticator, error) {
+	a := &Authenticator{
+		enabled: cfg.Enabled,
+		apiKeys: make(map[string]*Principal, len(cfg.APIKeys)),
+	}
+
+	for _, key := range cfg.APIKeys {
+		a.apiKeys[strings.ToLower(key.KeyHash)] = &Principal{
+			ID:     key.ClientID,
+			Method: MethodAPIKey,
+			Scopes: key.Scopes,
+		}
+	}
+
+	if cfg.DBAPIKeys {
+		a.keyRepo = keyRepo
+	}
+
+	if cfg.JWKSFile != "" {
+		jwks, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks = jwks
+
+		options := []jwt.ParserOption{
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}),
+			jwt.WithExpirationRequired(),
+		}
+		if cfg.JWTIssuer != "" {
+			options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+		}
+		if cfg.JWTAudience != "" {
+			options = append(options, jwt.WithAudience(cfg.JWTAudience))
+		}
+		a.jwtParser = jwt.NewParser(options...)
+	}
+
+	return a, nil
+}
+
+// Enabled сообщает, нужно ли проверять запросы
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// HashAPIKey возвращает хеш ключа в том виде, в котором он хранится в конфиге и таблице api_keys
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate проверяет учетные данные запроса: bearer токен (JWT) или API ключ
+func (a *Authenticator) Authenticate(ctx context.Context, bearerToken, apiKey string) (*Principal, error) {
+	switch {
+	case apiKey != "":
+		return a.authenticateAPIKey(ctx, apiKey)
+	case bearerToken != "":
+		return a.authenticateJWT(bearerToken)
+	default:
+		metrics.AuthFailures.WithLabelValues("missing_credentials").Inc()
+		return nil, domain.Unauthenticated("missing_credentials", "api key or bearer token is required")
+	}
+}
+
+// Authorize проверяет, что у клиента есть нужная область доступа
+func (a *Authenticator) Authorize(p *Principal, scope string) error {
+	if !p.HasScope(scope) {
+		metrics.AuthFailures.WithLabelValues("insufficient_scope").Inc()
+		return domain.Forbidden("insufficient_scope", fmt.Sprintf("scope %s is required", scope))
+	}
+	return nil
+}
+
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, apiKey string) (*Principal, error) {
+	hash := HashAPIKey(apiKey)
+
+	if p, ok := a.apiKeys[hash]; ok {
+		return p, nil
+	}
+
+	if a.keyRepo != nil {
+		key, err := a.keyRepo.GetByHash(ctx, hash)
+		switch {
+		case err == nil:
+			return &Principal{ID: key.ClientID, Method: MethodAPIKey, Scopes: key.Scopes}, nil
+		case !errors.Is(err, domain.ErrNotFound):
+			return nil, err
+		}
+	}
+
+	metrics.AuthFailures.WithLabelValues("invalid_api_key").Inc()
+	return nil, domain.Unauthenticated("invalid_api_key", "api key is invalid or revoked")
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope    string   `json:"scope"`
+	Scp      []string `json:"scp"`
+	ClientID string   `json:"client_id"`
+}
+
+func (a *Authenticator) authenticateJWT(token string) (*Principal, error) {
+	if a.jwtParser == nil {
+		metrics.AuthFailures.WithLabelValues("jwt_not_supported").Inc()
+		return nil, domain.Unauthenticated("jwt_not_supported", "bearer tokens are not accepted")
+	}
+
+	var claims tokenClaims
+	_, err := a.jwtParser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := a.jwks[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		// Причина (неизвестный kid, истекший срок, чужой issuer) остается в логе сервера,
+		// клиент получает только факт отказа
+		logger.Info("Bearer token rejected", zap.Error(err))
+		metrics.AuthFailures.WithLabelValues("invalid_token").Inc()
+		return nil, domain.Unauthenticated("invalid_token", "bearer token is invalid")
+	}
+
+	id := claims.ClientID
+	if id == "" {
+		id = claims.Subject
+	}
+	if id == "" {
+		metrics.AuthFailures.WithLabelValues("invalid_token").Inc()
+		return nil, domain.Unauthenticated("invalid_token", "bearer token has no subject")
+	}
+
+	scopes := claims.Scp
+	if claims.Scope != "" {
+		scopes = append(scopes, strings.Fields(claims.Scope)...)
+	}
+
+	return &Principal{ID: id, Method: MethodJWT, Scopes: scopes}, nil
+}
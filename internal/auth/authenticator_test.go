@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, *ecdsa.PrivateKey) {
+	t.Helper()
+	logger.Init(zapcore.NewNopCore())
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Authenticator{
+		enabled: true,
+		jwks:    map[string]crypto.PublicKey{"test": &key.PublicKey},
+		jwtParser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"ES256"}),
+			jwt.WithExpirationRequired(),
+			jwt.WithAudience("flight-service"),
+		),
+	}, key
+}
+
+func signToken(t *testing.T, key *ecdsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	a, key := newTestAuthenticator(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := jwt.MapClaims{
+		"sub":   "client-1",
+		"aud":   "flight-service",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "flights:read flights:write",
+	}
+	with := func(key string, value any) jwt.MapClaims {
+		claims := jwt.MapClaims{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+		return claims
+	}
+
+	principal, err := a.authenticateJWT(signToken(t, key, "test", valid))
+	if err != nil {
+		t.Fatalf("authenticateJWT(valid) error = %v", err)
+	}
+	if principal.ID != "client-1" || len(principal.Scopes) != 2 {
+		t.Errorf("principal = %+v, want client-1 with 2 scopes", principal)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown key id", signToken(t, key, "rotated", valid)},
+		{"wrong signature", signToken(t, otherKey, "test", valid)},
+		{"expired", signToken(t, key, "test", with("exp", time.Now().Add(-time.Minute).Unix()))},
+		{"wrong audience", signToken(t, key, "test", with("aud", "other-service"))},
+		{"malformed", "not.a.jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.authenticateJWT(tt.token)
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrUnauthenticated) {
+				t.Fatalf("authenticateJWT() error = %v, want unauthenticated domain error", err)
+			}
+			// Детали проверки JWT не должны попадать в ответ клиенту
+			if domainErr.Code != "invalid_token" || domainErr.Message != "bearer token is invalid" {
+				t.Errorf("error = %q (%s), want fixed invalid_token message", domainErr.Message, domainErr.Code)
+			}
+			if strings.Contains(err.Error(), "rotated") {
+				t.Errorf("error %q leaks the key id", err.Error())
+			}
+		})
+	}
+}
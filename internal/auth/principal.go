@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Области доступа, сопоставленные операциям API
+const (
+	ScopeFlightsRead  = "flights:read"
+	ScopeFlightsWrite = "flights:write"
+	ScopeWebhooks     = "webhooks"
+	ScopeAdmin        = "admin"
+)
+
+// Способы аутентификации клиента
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal аутентифицированный клиент
+type Principal struct {
+	ID     string
+	Method string
+	Scopes []string
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext возвращает клиента запроса или nil, если аутентификация отключена
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// CallerID возвращает идентификатор клиента запроса или пустую строку
+func CallerID(ctx context.Context) string {
+	if p := PrincipalFromContext(ctx); p != nil {
+		return p.ID
+	}
+	return ""
+}
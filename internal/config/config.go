@@ -1,28 +1,57 @@
 package config
 
+import "time"
+
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
+	Server    ServerConfig    `mapstructure:"server"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Redis     RedisConfig     `mapstructure:"redis"`
+	Kafka     KafkaConfig     `mapstructure:"kafka"`
+	Logger    LoggerConfig    `mapstructure:"logger"`
+	Meta      MetaConfig      `mapstructure:"meta"`
+	Search    SearchConfig    `mapstructure:"search"`
+	GRPC      GRPCConfig      `mapstructure:"grpc"`
+	Auth      AuthConfig      `mapstructure:"auth"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	Webhooks  WebhooksConfig  `mapstructure:"webhooks"`
+	Stream    StreamConfig    `mapstructure:"stream"`
 }
 
 type ServerConfig struct {
 	Port string `mapstructure:"port"`
 }
 
+type GRPCConfig struct {
+	Port          string        `mapstructure:"port"`
+	WatchInterval time.Duration `mapstructure:"watch_interval"` // период опроса изменений для WatchFlight
+}
+
 type DatabaseConfig struct {
 	Host     string `mapstructure:"host"`
 	Port     string `mapstructure:"port"`
 	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
+	Password string `mapstructure:"password" secret:"true"`
 	Name     string `mapstructure:"name"`
+
+	SSLMode     string `mapstructure:"sslmode"` // disable, allow, prefer, require, verify-ca, verify-full
+	SSLRootCert string `mapstructure:"sslrootcert"`
+	SSLCert     string `mapstructure:"sslcert"`
+	SSLKey      string `mapstructure:"sslkey"`
+
+	MaxConns         int32         `mapstructure:"max_conns"`
+	MinConns         int32         `mapstructure:"min_conns"`
+	MaxConnLifetime  time.Duration `mapstructure:"max_conn_lifetime"`
+	MaxConnIdleTime  time.Duration `mapstructure:"max_conn_idle_time"`
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"` // 0 - без ограничения
+	ApplicationName  string        `mapstructure:"application_name"`
+
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // 0 - не логировать медленные запросы
 }
 
 type RedisConfig struct {
 	Host     string `mapstructure:"host"`
 	Port     string `mapstructure:"port"`
-	Password string `mapstructure:"password"`
+	Password string `mapstructure:"password" secret:"true"`
 	DB       int    `mapstructure:"db"`
 }
 
@@ -30,4 +59,150 @@ type KafkaConfig struct {
 	KafkaBrokers []string `mapstructure:"brokers"`
 	GroupID      string   `mapstructure:"group_id"`
 	Topic        string   `mapstructure:"topic"`
+
+	Producer KafkaProducerConfig `mapstructure:"producer"`
+	Consumer KafkaConsumerConfig `mapstructure:"consumer"`
+	Events   KafkaEventsConfig   `mapstructure:"events"`
+}
+
+// KafkaEventsConfig исходящие события flight.updated, отправляемые через outbox,
+// а в транзакционном режиме consumer - в его Kafka транзакции
+type KafkaEventsConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Topic         string        `mapstructure:"topic"` // сжатый (cleanup.policy=compact) топик с ключом по рейсу
+	RelayInterval time.Duration `mapstructure:"relay_interval"`
+	BatchSize     int           `mapstructure:"batch_size"`
+}
+
+// KafkaProducerConfig очередь отправки в Kafka и поведение при ее переполнении
+type KafkaProducerConfig struct {
+	QueueSize          int           `mapstructure:"queue_size"`
+	EnqueueTimeout     time.Duration `mapstructure:"enqueue_timeout"`
+	OverflowPolicy     string        `mapstructure:"overflow_policy"` // block, reject, spill
+	SpillDrainInterval time.Duration `mapstructure:"spill_drain_interval"`
+
+	RequiredAcks   string        `mapstructure:"required_acks"` // NoResponse, WaitForLocal, WaitForAll
+	RetryMax       int           `mapstructure:"retry_max"`
+	Compression    string        `mapstructure:"compression"` // none, gzip, snappy, lz4, zstd
+	Idempotent     bool          `mapstructure:"idempotent"`
+	FlushFrequency time.Duration `mapstructure:"flush_frequency"`
+	FlushMessages  int           `mapstructure:"flush_messages"`
+	FlushBytes     int           `mapstructure:"flush_bytes"`
+}
+
+type KafkaConsumerConfig struct {
+	RetryAttempts int           `mapstructure:"retry_attempts"`
+	RetryDelay    time.Duration `mapstructure:"retry_delay"`
+
+	// Transactional включает exactly-once: offset коммитится в Kafka транзакции вместе с событием
+	// flight.updated (outbox в этом режиме не используется), повторы отсекаются по processed_offsets.
+	// TransactionalID по умолчанию строится из имени хоста.
+	Transactional   bool   `mapstructure:"transactional"`
+	TransactionalID string `mapstructure:"transactional_id"`
+}
+
+// MetaConfig настройки выдачи истории обработки рейса
+type MetaConfig struct {
+	DefaultLimit int `mapstructure:"default_limit"`
+	MaxLimit     int `mapstructure:"max_limit"`
+}
+
+// LoggerConfig настройки логирования
+type LoggerConfig struct {
+	Level   string        `mapstructure:"level"`  // debug, info, warn, error
+	Format  string        `mapstructure:"format"` // console, json
+	Console bool          `mapstructure:"console"`
+	File    LogFileConfig `mapstructure:"file"`
+}
+
+// LogFileConfig настройки записи логов в файл с ротацией
+type LogFileConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Path       string `mapstructure:"path"`
+	MaxSize    int    `mapstructure:"max_size"` // в мегабайтах
+	MaxBackups int    `mapstructure:"max_backups"`
+	MaxAge     int    `mapstructure:"max_age"` // в днях
+	Compress   bool   `mapstructure:"compress"`
+}
+
+// SearchConfig настройки выдачи поиска рейсов
+type SearchConfig struct {
+	DefaultLimit int `mapstructure:"default_limit"`
+	MaxLimit     int `mapstructure:"max_limit"`
+}
+
+// AuthConfig настройки аутентификации клиентов по API ключам и JWT
+type AuthConfig struct {
+	Enabled     bool           `mapstructure:"enabled"`
+	APIKeys     []APIKeyConfig `mapstructure:"api_keys" secret:"true"`
+	DBAPIKeys   bool           `mapstructure:"db_api_keys"` // дополнительно искать ключи в таблице api_keys
+	JWKSFile    string         `mapstructure:"jwks_file"`   // пусто - JWT не принимаются
+	JWTIssuer   string         `mapstructure:"jwt_issuer"`
+	JWTAudience string         `mapstructure:"jwt_audience"`
+}
+
+type APIKeyConfig struct {
+	ClientID string   `mapstructure:"client_id"`
+	KeyHash  string   `mapstructure:"key_hash"` // sha256 от ключа в hex
+	Scopes   []string `mapstructure:"scopes"`
+}
+
+// RateLimitConfig лимиты запросов на клиента и маршрут (token bucket)
+type RateLimitConfig struct {
+	Enabled bool                     `mapstructure:"enabled"`
+	Backend string                   `mapstructure:"backend"` // memory, redis
+	Default RateLimitRule            `mapstructure:"default"`
+	PerIP   RateLimitRule            `mapstructure:"per_ip"`  // лимит на IP до аутентификации, для всех маршрутов вместе
+	Routes  []RateLimitRoute         `mapstructure:"routes"`  // списком, так как имена gRPC методов содержат точки
+	Clients map[string]RateLimitRule `mapstructure:"clients"` // ключ client_id, имеет приоритет над routes
+}
+
+type RateLimitRule struct {
+	Rate  float64 `mapstructure:"rate"` // запросов в секунду
+	Burst int     `mapstructure:"burst"`
+}
+
+// RateLimitRoute лимит маршрута "METHOD /path" или полного имени gRPC метода
+type RateLimitRoute struct {
+	Route string  `mapstructure:"route"`
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
+func (r RateLimitRoute) Rule() RateLimitRule {
+	return RateLimitRule{Rate: r.Rate, Burst: r.Burst}
+}
+
+// WebhooksConfig доставка уведомлений об изменениях рейсов подписчикам
+type WebhooksConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	Timeout      time.Duration `mapstructure:"timeout"` // таймаут одного HTTP запроса
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	BackoffBase  time.Duration `mapstructure:"backoff_base"`
+	BackoffMax   time.Duration `mapstructure:"backoff_max"`
+	DisableAfter int           `mapstructure:"disable_after"` // ошибок подряд до отключения подписки
+
+	// AllowPrivateTargets разрешает доставку на loopback, частные и link-local адреса,
+	// только для локальной разработки
+	AllowPrivateTargets bool `mapstructure:"allow_private_targets"`
+}
+
+// StreamConfig поток обновлений рейсов через Server-Sent Events. Реплики получают события
+// через Postgres LISTEN/NOTIFY, журнал событий хранится retention для возобновления по Last-Event-ID.
+//
+// Ограничение пропускной способности: ID событий выдаются под одним глобальным advisory lock,
+// который держится до коммита транзакции обработки рейса. При включенном потоке коммиты всех
+// рейсов идут по одному, и запись ограничена примерно 1 / (время коммита с fsync WAL)
+// транзакций в секунду на весь кластер, независимо от числа реплик и партиций Kafka.
+// Если этого мало, поток нужно выключить.
+type StreamConfig struct {
+	Enabled           bool          `mapstructure:"enabled"`
+	Channel           string        `mapstructure:"channel"`     // канал LISTEN/NOTIFY
+	BufferSize        int           `mapstructure:"buffer_size"` // событий в очереди клиента, при переполнении клиент отключается
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	ReplayLimit       int           `mapstructure:"replay_limit"` // максимум событий, досылаемых при переподключении, при большем отставании клиент получает reset
+	Retention         time.Duration `mapstructure:"retention"`
+	CleanupInterval   time.Duration `mapstructure:"cleanup_interval"`
 }
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix префикс переменных окружения, переопределяющих параметры конфига.
+// Например, database.password задается через FLIGHT_DATABASE_PASSWORD
+// или читается из файла, путь к которому лежит в FLIGHT_DATABASE_PASSWORD_FILE.
+const EnvPrefix = "FLIGHT"
+
+const redactedValue = "******"
+
+// bindEnvs привязывает каждый ключ конфига к переменной окружения с префиксом
+// и подставляет значения из файлов, указанных в *_FILE переменных
+func bindEnvs() error {
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := viper.BindEnv(key); err != nil {
+			return fmt.Errorf("failed to bind env for %s: %w", key, err)
+		}
+
+		fileEnv := envName(key) + "_FILE"
+		path, ok := os.LookupEnv(fileEnv)
+		if !ok || path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from %s: %w", key, fileEnv, err)
+		}
+		viper.Set(key, strings.TrimSpace(string(content)))
+	}
+
+	return nil
+}
+
+// envName возвращает имя переменной окружения для ключа конфига
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// configKeys собирает ключи всех конечных полей структуры по тегам mapstructure
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := joinKey(prefix, field.Tag.Get("mapstructure"))
+
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, configKeys(field.Type, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// redactedMap превращает структуру в map по тегам mapstructure, скрывая поля с тегом secret
+func redactedMap(v reflect.Value) map[string]any {
+	result := make(map[string]any, v.NumField())
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		value := v.Field(i)
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			result[name] = redactedMap(value)
+		case field.Tag.Get("secret") == "true" && !value.IsZero():
+			result[name] = redactedValue
+		default:
+			result[name] = value.Interface()
+		}
+	}
+	return result
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
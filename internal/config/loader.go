@@ -6,7 +6,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 )
 
 func LoadConfig() (*Config, error) {
@@ -24,18 +26,200 @@ func LoadConfig() (*Config, error) {
 	}
 	viper.AddConfigPath(absPath)
 
+	setDefaults()
+
+	viper.SetEnvPrefix(EnvPrefix)
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AllowEmptyEnv(true)
+	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	setConfigRead()
+
+	cfg, err := decode()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Loaded config: %s", env)
+	return cfg, nil
+}
+
+// override значение ключа из флага командной строки или /admin API
+type override struct {
+	value  any
+	source any  // значение ключа в файле и окружении, поверх которого действует переопределение
+	seen   bool // source уже прочитан
+}
+
+var (
+	overridesMu sync.Mutex
+	overrides   = make(map[string]*override)
+	configRead  bool // файл конфига уже прочитан, источник переопределения известен сразу
+)
+
+func setConfigRead() {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	configRead = true
+}
+
+// SetOverride задает значение ключа поверх файла и окружения (например, из флага командной строки).
+// Переопределение действует, пока значение ключа в конфиге не изменится: если ключ поменяли
+// в файле, при перечитывании побеждает новое значение из файла.
+func SetOverride(key string, value any) {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+
+	o := &override{value: value}
+	// До первого чтения файла источник запоминается в decode
+	if configRead {
+		o.source, o.seen = viper.Get(key), true
+	}
+	overrides[key] = o
+}
+
+// decode собирает Config из прочитанного файла, переменных окружения и переопределений
+func decode() (*Config, error) {
+	if err := bindEnvs(); err != nil {
+		return nil, err
+	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	log.Printf("Loaded config: %s", env)
+	if err := applyOverrides(&cfg); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+// applyOverrides подставляет в cfg переопределения, ключи которых не менялись в файле и окружении
+func applyOverrides(cfg *Config) error {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+
+	for key, o := range overrides {
+		current := viper.Get(key)
+		if !o.seen {
+			o.source, o.seen = current, true
+		}
+		if !reflect.DeepEqual(current, o.source) {
+			delete(overrides, key)
+			continue
+		}
+		if err := setField(reflect.ValueOf(cfg).Elem(), key, o.value); err != nil {
+			return fmt.Errorf("failed to apply override for %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// setField записывает value в поле структуры по ключу конфига из тегов mapstructure
+func setField(v reflect.Value, key string, value any) error {
+	name, rest, nested := strings.Cut(key, ".")
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("mapstructure") != name {
+			continue
+		}
+		field := v.Field(i)
+		if nested {
+			if field.Kind() != reflect.Struct {
+				return fmt.Errorf("%s is not a section", name)
+			}
+			return setField(field, rest, value)
+		}
+
+		val := reflect.ValueOf(value)
+		if !val.IsValid() || !val.Type().AssignableTo(field.Type()) {
+			return fmt.Errorf("cannot use %T as %s", value, field.Type())
+		}
+		field.Set(val)
+		return nil
+	}
+	return fmt.Errorf("unknown key %s", name)
+}
+
+// setDefaults задает значения по умолчанию для необязательных параметров
+func setDefaults() {
+	viper.SetDefault("database.sslmode", "disable")
+	viper.SetDefault("database.max_conns", 10)
+	viper.SetDefault("database.min_conns", 0)
+	viper.SetDefault("database.max_conn_lifetime", "1h")
+	viper.SetDefault("database.max_conn_idle_time", "30m")
+	viper.SetDefault("database.statement_timeout", "0s")
+	viper.SetDefault("database.application_name", "flight-service")
+	viper.SetDefault("database.slow_query_threshold", "500ms")
+
+	viper.SetDefault("logger.level", "info")
+	viper.SetDefault("logger.format", "console")
+	viper.SetDefault("logger.console", true)
+	viper.SetDefault("logger.file.enabled", true)
+	viper.SetDefault("logger.file.path", "logs/app.log")
+	viper.SetDefault("logger.file.max_size", 10)
+	viper.SetDefault("logger.file.max_backups", 3)
+	viper.SetDefault("logger.file.max_age", 7)
+	viper.SetDefault("logger.file.compress", false)
+
+	viper.SetDefault("meta.default_limit", 50)
+	viper.SetDefault("meta.max_limit", 100)
+
+	viper.SetDefault("search.default_limit", 50)
+	viper.SetDefault("search.max_limit", 500)
+
+	viper.SetDefault("auth.enabled", true)
+	viper.SetDefault("auth.db_api_keys", false)
+
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.backend", "memory")
+	viper.SetDefault("rate_limit.default.rate", 50)
+	viper.SetDefault("rate_limit.default.burst", 100)
+	viper.SetDefault("rate_limit.per_ip.rate", 200)
+	viper.SetDefault("rate_limit.per_ip.burst", 400)
+
+	viper.SetDefault("webhooks.enabled", true)
+	viper.SetDefault("webhooks.poll_interval", "1s")
+	viper.SetDefault("webhooks.batch_size", 50)
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.max_attempts", 8)
+	viper.SetDefault("webhooks.backoff_base", "10s")
+	viper.SetDefault("webhooks.backoff_max", "1h")
+	viper.SetDefault("webhooks.disable_after", 20)
+	viper.SetDefault("webhooks.allow_private_targets", false)
+
+	viper.SetDefault("stream.enabled", true)
+	viper.SetDefault("stream.channel", "flight_stream")
+	viper.SetDefault("stream.buffer_size", 256)
+	viper.SetDefault("stream.heartbeat_interval", "15s")
+	viper.SetDefault("stream.replay_limit", 1000)
+	viper.SetDefault("stream.retention", "24h")
+	viper.SetDefault("stream.cleanup_interval", "10m")
+
+	viper.SetDefault("grpc.port", ":50051")
+	viper.SetDefault("grpc.watch_interval", "2s")
+
+	viper.SetDefault("kafka.producer.queue_size", 100)
+	viper.SetDefault("kafka.producer.enqueue_timeout", "2s")
+	viper.SetDefault("kafka.producer.overflow_policy", "block")
+	viper.SetDefault("kafka.producer.spill_drain_interval", "5s")
+	viper.SetDefault("kafka.producer.required_acks", "WaitForAll")
+	viper.SetDefault("kafka.producer.retry_max", 3)
+	viper.SetDefault("kafka.producer.compression", "snappy")
+	viper.SetDefault("kafka.producer.idempotent", true)
+	viper.SetDefault("kafka.producer.flush_frequency", "10ms")
+	viper.SetDefault("kafka.producer.flush_messages", 100)
+	viper.SetDefault("kafka.producer.flush_bytes", 64*1024)
+	viper.SetDefault("kafka.events.enabled", true)
+	viper.SetDefault("kafka.events.topic", "flight-updated")
+	viper.SetDefault("kafka.events.relay_interval", "1s")
+	viper.SetDefault("kafka.events.batch_size", 100)
+	viper.SetDefault("kafka.consumer.retry_attempts", 3)
+	viper.SetDefault("kafka.consumer.retry_delay", "5s")
+	viper.SetDefault("kafka.consumer.transactional", false)
+}
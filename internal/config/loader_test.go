@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// readTestConfig записывает YAML с logger.level во временный файл и читает его глобальным viper
+func readTestConfig(t *testing.T, path, level string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("logger:\n  level: "+level+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig() error = %v", err)
+	}
+	setConfigRead()
+}
+
+func resetTestConfig(t *testing.T) string {
+	t.Helper()
+	viper.Reset()
+	overrides, configRead = make(map[string]*override), false
+	t.Cleanup(func() {
+		viper.Reset()
+		overrides, configRead = make(map[string]*override), false
+	})
+
+	path := filepath.Join(t.TempDir(), "test.yml")
+	viper.SetConfigFile(path)
+	return path
+}
+
+func decodeConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := decode()
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	return cfg
+}
+
+func decodeLevel(t *testing.T) string {
+	t.Helper()
+	return decodeConfig(t).Logger.Level
+}
+
+// Флаг побеждает файл, пока logger.level в файле не изменят
+func TestSetOverride(t *testing.T) {
+	path := resetTestConfig(t)
+
+	SetOverride("logger.level", "debug")
+	readTestConfig(t, path, "info")
+	if got := decodeLevel(t); got != "debug" {
+		t.Fatalf("level after load = %q, want debug from override", got)
+	}
+
+	// Перечитывание без изменения ключа сохраняет переопределение
+	readTestConfig(t, path, "info")
+	if got := decodeLevel(t); got != "debug" {
+		t.Fatalf("level after reload = %q, want debug from override", got)
+	}
+
+	readTestConfig(t, path, "warn")
+	if got := decodeLevel(t); got != "warn" {
+		t.Fatalf("level after file change = %q, want warn from file", got)
+	}
+
+	// Переопределение больше не действует, даже если файл вернули к прежнему значению
+	readTestConfig(t, path, "info")
+	if got := decodeLevel(t); got != "info" {
+		t.Fatalf("level after second file change = %q, want info from file", got)
+	}
+}
+
+func TestSetOverrideUnknownKey(t *testing.T) {
+	path := resetTestConfig(t)
+
+	SetOverride("logger.verbosity", "debug")
+	readTestConfig(t, path, "info")
+	if _, err := decode(); err == nil {
+		t.Fatal("decode() error = nil, want error for unknown key")
+	}
+}
+
+// Уровень из /admin/loglevel попадает в Store и переживает перечитывание файла
+func TestStoreSetLogLevel(t *testing.T) {
+	path := resetTestConfig(t)
+	readTestConfig(t, path, "info")
+
+	store := NewStore(decodeConfig(t))
+
+	var notified string
+	store.Subscribe(func(old, next *Config) {
+		notified = next.Logger.Level
+	})
+
+	store.SetLogLevel("debug")
+	if got := store.Current().Logger.Level; got != "debug" {
+		t.Fatalf("Current().Logger.Level = %q, want debug", got)
+	}
+	if notified != "debug" {
+		t.Fatalf("subscriber got level %q, want debug", notified)
+	}
+
+	// Как reload, без Validate: тестовый конфиг содержит только logger.level
+	readTestConfig(t, path, "info")
+	store.Update(decodeConfig(t))
+	if got := store.Current().Logger.Level; got != "debug" {
+		t.Fatalf("level after unrelated reload = %q, want debug", got)
+	}
+
+	readTestConfig(t, path, "error")
+	store.Update(decodeConfig(t))
+	if got := store.Current().Logger.Level; got != "error" {
+		t.Fatalf("level after file change = %q, want error", got)
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Print выводит итоговый конфиг в формате YAML, скрывая секреты
+func Print(w io.Writer, cfg *Config) error {
+	out, err := yaml.Marshal(redactedMap(reflect.ValueOf(*cfg)))
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	_, err = w.Write(out)
+	return err
+}
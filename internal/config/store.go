@@ -0,0 +1,135 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// reloadableKeys параметры, которые можно менять без перезапуска сервиса
+var reloadableKeys = map[string]bool{
+	"logger.level":                  true,
+	"database.slow_query_threshold": true,
+	"meta.default_limit":            true,
+	"meta.max_limit":                true,
+	"search.default_limit":          true,
+	"search.max_limit":              true,
+	"rate_limit.enabled":            true,
+	"rate_limit.default.rate":       true,
+	"rate_limit.default.burst":      true,
+	"rate_limit.per_ip.rate":        true,
+	"rate_limit.per_ip.burst":       true,
+	"rate_limit.routes":             true,
+	"rate_limit.clients":            true,
+	"kafka.consumer.retry_attempts": true,
+	"kafka.consumer.retry_delay":    true,
+}
+
+// Store хранит актуальный конфиг и оповещает подписчиков об изменениях
+type Store struct {
+	mu          sync.RWMutex
+	reloadMu    sync.Mutex
+	current     *Config
+	subscribers []func(old, next *Config)
+}
+
+// NewStore создает хранилище с начальным конфигом
+func NewStore(cfg *Config) *Store {
+	return &Store{current: cfg}
+}
+
+// Current возвращает актуальный конфиг. Возвращаемое значение нельзя изменять.
+func (s *Store) Current() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Subscribe регистрирует функцию, вызываемую после каждого применения нового конфига
+func (s *Store) Subscribe(fn func(old, next *Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Update применяет из next только параметры, допускающие перезагрузку.
+// Остальные изменения не применяются и возвращаются списком ключей, требующих перезапуска.
+func (s *Store) Update(next *Config) (applied []string, restartRequired []string) {
+	s.mu.Lock()
+	old := s.current
+
+	oldValues := flatten(reflect.ValueOf(*old), "")
+	newValues := flatten(reflect.ValueOf(*next), "")
+
+	for key, value := range newValues {
+		if reflect.DeepEqual(oldValues[key], value) {
+			continue
+		}
+		if reloadableKeys[key] {
+			applied = append(applied, key)
+		} else {
+			restartRequired = append(restartRequired, key)
+		}
+	}
+	sort.Strings(applied)
+	sort.Strings(restartRequired)
+
+	if len(applied) == 0 {
+		s.mu.Unlock()
+		return applied, restartRequired
+	}
+
+	updated := *old
+	updated.Logger.Level = next.Logger.Level
+	updated.Database.SlowQueryThreshold = next.Database.SlowQueryThreshold
+	updated.Meta = next.Meta
+	updated.Search = next.Search
+	updated.RateLimit.Enabled = next.RateLimit.Enabled
+	updated.RateLimit.Default = next.RateLimit.Default
+	updated.RateLimit.PerIP = next.RateLimit.PerIP
+	updated.RateLimit.Routes = next.RateLimit.Routes
+	updated.RateLimit.Clients = next.RateLimit.Clients
+	updated.Kafka.Consumer.RetryAttempts = next.Kafka.Consumer.RetryAttempts
+	updated.Kafka.Consumer.RetryDelay = next.Kafka.Consumer.RetryDelay
+	s.current = &updated
+
+	subscribers := make([]func(old, next *Config), len(s.subscribers))
+	copy(subscribers, s.subscribers)
+	s.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(old, &updated)
+	}
+
+	return applied, restartRequired
+}
+
+// SetLogLevel меняет logger.level во время работы (PUT /admin/loglevel) и оповещает подписчиков.
+// Уровень сохраняется при перечитывании конфига, пока logger.level не изменят в файле.
+func (s *Store) SetLogLevel(level string) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	SetOverride("logger.level", level)
+
+	next := *s.Current()
+	next.Logger.Level = level
+	s.Update(&next)
+}
+
+// flatten раскладывает структуру в map ключ конфига -> значение
+func flatten(v reflect.Value, prefix string) map[string]any {
+	result := make(map[string]any)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := joinKey(prefix, t.Field(i).Tag.Get("mapstructure"))
+		if t.Field(i).Type.Kind() == reflect.Struct {
+			for k, val := range flatten(v.Field(i), key) {
+				result[k] = val
+			}
+			continue
+		}
+		result[key] = v.Field(i).Interface()
+	}
+	return result
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var streamChannel = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// Validate проверяет конфиг и возвращает все найденные ошибки разом
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.Server.Port); err != nil {
+		errs = append(errs, fmt.Errorf("server.port: invalid address %q", c.Server.Port))
+	}
+	if _, _, err := net.SplitHostPort(c.GRPC.Port); err != nil {
+		errs = append(errs, fmt.Errorf("grpc.port: invalid address %q", c.GRPC.Port))
+	}
+	if c.GRPC.WatchInterval <= 0 {
+		errs = append(errs, fmt.Errorf("grpc.watch_interval: must be positive"))
+	}
+
+	errs = append(errs, required("database.host", c.Database.Host))
+	errs = append(errs, validPort("database.port", c.Database.Port))
+	errs = append(errs, required("database.user", c.Database.User))
+	errs = append(errs, required("database.name", c.Database.Name))
+	switch c.Database.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("database.sslmode: unknown mode %q", c.Database.SSLMode))
+	}
+	if (c.Database.SSLCert == "") != (c.Database.SSLKey == "") {
+		errs = append(errs, fmt.Errorf("database.sslcert and database.sslkey must be set together"))
+	}
+	if c.Database.MaxConns <= 0 {
+		errs = append(errs, fmt.Errorf("database.max_conns: must be positive"))
+	}
+	if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+		errs = append(errs, fmt.Errorf("database.min_conns: must be between 0 and database.max_conns"))
+	}
+	if c.Database.MaxConnLifetime < 0 || c.Database.MaxConnIdleTime < 0 || c.Database.StatementTimeout < 0 || c.Database.SlowQueryThreshold < 0 {
+		errs = append(errs, fmt.Errorf("database: durations must not be negative"))
+	}
+
+	if c.Redis.Host != "" {
+		errs = append(errs, validPort("redis.port", c.Redis.Port))
+	}
+	if c.Redis.DB < 0 {
+		errs = append(errs, fmt.Errorf("redis.db: must not be negative"))
+	}
+
+	if len(c.Kafka.KafkaBrokers) == 0 {
+		errs = append(errs, fmt.Errorf("kafka.brokers: at least one broker is required"))
+	}
+	for i, broker := range c.Kafka.KafkaBrokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			errs = append(errs, fmt.Errorf("kafka.brokers[%d]: invalid address %q", i, broker))
+		}
+	}
+	errs = append(errs, required("kafka.group_id", c.Kafka.GroupID))
+	errs = append(errs, required("kafka.topic", c.Kafka.Topic))
+	if c.Kafka.Producer.QueueSize < 1 {
+		errs = append(errs, fmt.Errorf("kafka.producer.queue_size: must be at least 1"))
+	}
+	if c.Kafka.Producer.EnqueueTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("kafka.producer.enqueue_timeout: must be positive"))
+	}
+	switch c.Kafka.Producer.OverflowPolicy {
+	case "block", "reject", "spill":
+	default:
+		errs = append(errs, fmt.Errorf("kafka.producer.overflow_policy: must be block, reject or spill, got %q", c.Kafka.Producer.OverflowPolicy))
+	}
+	if c.Kafka.Producer.OverflowPolicy == "spill" && c.Kafka.Producer.SpillDrainInterval <= 0 {
+		errs = append(errs, fmt.Errorf("kafka.producer.spill_drain_interval: must be positive"))
+	}
+	if c.Kafka.Producer.Idempotent && c.Kafka.Producer.RetryMax < 1 {
+		errs = append(errs, fmt.Errorf("kafka.producer.retry_max: must be at least 1 for idempotent producer"))
+	}
+	if c.Kafka.Producer.Idempotent && c.Kafka.Producer.RequiredAcks != "WaitForAll" {
+		errs = append(errs, fmt.Errorf("kafka.producer.required_acks: must be WaitForAll for idempotent producer"))
+	}
+	if c.Kafka.Producer.FlushFrequency < 0 || c.Kafka.Producer.FlushMessages < 0 || c.Kafka.Producer.FlushBytes < 0 {
+		errs = append(errs, fmt.Errorf("kafka.producer.flush_*: must not be negative"))
+	}
+	if c.Kafka.Events.Enabled {
+		errs = append(errs, required("kafka.events.topic", c.Kafka.Events.Topic))
+		if c.Kafka.Events.RelayInterval <= 0 {
+			errs = append(errs, fmt.Errorf("kafka.events.relay_interval: must be positive"))
+		}
+		if c.Kafka.Events.BatchSize < 1 {
+			errs = append(errs, fmt.Errorf("kafka.events.batch_size: must be at least 1"))
+		}
+	}
+	if c.Kafka.Consumer.RetryAttempts < 1 {
+		errs = append(errs, fmt.Errorf("kafka.consumer.retry_attempts: must be at least 1"))
+	}
+	if c.Kafka.Consumer.RetryDelay < 0 {
+		errs = append(errs, fmt.Errorf("kafka.consumer.retry_delay: must not be negative"))
+	}
+
+	if c.Meta.DefaultLimit <= 0 {
+		errs = append(errs, fmt.Errorf("meta.default_limit: must be positive"))
+	}
+	if c.Meta.MaxLimit < c.Meta.DefaultLimit {
+		errs = append(errs, fmt.Errorf("meta.max_limit: must not be less than meta.default_limit"))
+	}
+
+	if c.Search.DefaultLimit <= 0 {
+		errs = append(errs, fmt.Errorf("search.default_limit: must be positive"))
+	}
+	if c.Search.MaxLimit < c.Search.DefaultLimit {
+		errs = append(errs, fmt.Errorf("search.max_limit: must not be less than search.default_limit"))
+	}
+
+	if _, err := zapcore.ParseLevel(c.Logger.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logger.level: unknown level %q", c.Logger.Level))
+	}
+	if c.Logger.Format != "console" && c.Logger.Format != "json" {
+		errs = append(errs, fmt.Errorf("logger.format: must be console or json, got %q", c.Logger.Format))
+	}
+	if c.Logger.File.Enabled {
+		errs = append(errs, required("logger.file.path", c.Logger.File.Path))
+		if c.Logger.File.MaxSize < 0 || c.Logger.File.MaxBackups < 0 || c.Logger.File.MaxAge < 0 {
+			errs = append(errs, fmt.Errorf("logger.file: rotation settings must not be negative"))
+		}
+	}
+
+	if c.RateLimit.Backend != "memory" && c.RateLimit.Backend != "redis" {
+		errs = append(errs, fmt.Errorf("rate_limit.backend: must be memory or redis, got %q", c.RateLimit.Backend))
+	}
+	if c.RateLimit.Backend == "redis" {
+		errs = append(errs, required("redis.host", c.Redis.Host))
+	}
+	errs = append(errs, validRule("rate_limit.default", c.RateLimit.Default))
+	errs = append(errs, validRule("rate_limit.per_ip", c.RateLimit.PerIP))
+	routes := make(map[string]bool, len(c.RateLimit.Routes))
+	for i, route := range c.RateLimit.Routes {
+		key := fmt.Sprintf("rate_limit.routes[%d]", i)
+		switch {
+		case route.Route == "":
+			errs = append(errs, fmt.Errorf("%s.route: must not be empty", key))
+		case routes[route.Route]:
+			errs = append(errs, fmt.Errorf("%s.route: %q is already defined", key, route.Route))
+		}
+		routes[route.Route] = true
+		errs = append(errs, validRule(key, route.Rule()))
+	}
+	for client, rule := range c.RateLimit.Clients {
+		errs = append(errs, validRule("rate_limit.clients."+client, rule))
+	}
+
+	if c.Webhooks.Enabled {
+		if c.Webhooks.PollInterval <= 0 || c.Webhooks.Timeout <= 0 || c.Webhooks.BackoffBase <= 0 {
+			errs = append(errs, fmt.Errorf("webhooks: poll_interval, timeout and backoff_base must be positive"))
+		}
+		if c.Webhooks.BackoffMax < c.Webhooks.BackoffBase {
+			errs = append(errs, fmt.Errorf("webhooks.backoff_max: must not be less than backoff_base"))
+		}
+		if c.Webhooks.BatchSize < 1 || c.Webhooks.MaxAttempts < 1 || c.Webhooks.DisableAfter < 1 {
+			errs = append(errs, fmt.Errorf("webhooks: batch_size, max_attempts and disable_after must be at least 1"))
+		}
+	}
+
+	if c.Stream.Enabled {
+		if !streamChannel.MatchString(c.Stream.Channel) {
+			errs = append(errs, fmt.Errorf("stream.channel: must be a lowercase identifier, got %q", c.Stream.Channel))
+		}
+		if c.Stream.HeartbeatInterval <= 0 || c.Stream.Retention <= 0 || c.Stream.CleanupInterval <= 0 {
+			errs = append(errs, fmt.Errorf("stream: heartbeat_interval, retention and cleanup_interval must be positive"))
+		}
+		if c.Stream.BufferSize < 1 || c.Stream.ReplayLimit < 1 {
+			errs = append(errs, fmt.Errorf("stream: buffer_size and replay_limit must be at least 1"))
+		}
+	}
+
+	if c.Auth.Enabled {
+		if len(c.Auth.APIKeys) == 0 && !c.Auth.DBAPIKeys && c.Auth.JWKSFile == "" {
+			errs = append(errs, fmt.Errorf("auth: at least one of api_keys, db_api_keys or jwks_file is required when auth is enabled"))
+		}
+		for i, key := range c.Auth.APIKeys {
+			errs = append(errs, required(fmt.Sprintf("auth.api_keys[%d].client_id", i), key.ClientID))
+			if decoded, err := hex.DecodeString(key.KeyHash); err != nil || len(decoded) != sha256.Size {
+				errs = append(errs, fmt.Errorf("auth.api_keys[%d].key_hash: must be a hex encoded sha256", i))
+			}
+			if len(key.Scopes) == 0 {
+				errs = append(errs, fmt.Errorf("auth.api_keys[%d].scopes: at least one scope is required", i))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func required(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s: is required", key)
+	}
+	return nil
+}
+
+func validPort(key, value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 || port > 65535 {
+		return fmt.Errorf("%s: invalid port %q", key, value)
+	}
+	return nil
+}
+
+func validRule(key string, rule RateLimitRule) error {
+	if rule.Rate <= 0 || rule.Burst < 1 {
+		return fmt.Errorf("%s: rate must be positive and burst at least 1", key)
+	}
+	return nil
+}
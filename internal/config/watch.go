@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"flight-service/internal/logger"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Watch перечитывает конфиг при изменении YAML файла и по сигналу SIGHUP.
+// viper.WatchConfig читает файл в своей горутине без блокировки, поэтому файл отслеживается
+// здесь, а чтение и разбор глобального viper выполняются только в reload под reloadMu.
+func (s *Store) Watch(ctx context.Context) {
+	fileChanged := make(chan struct{}, 1)
+	if err := watchFile(ctx, viper.ConfigFileUsed(), fileChanged); err != nil {
+		logger.Error("Failed to watch config file, reload is available by SIGHUP only", zap.Error(err))
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-fileChanged:
+				logger.Info("Config file changed", zap.String("file", viper.ConfigFileUsed()))
+				s.reload()
+			case <-sigChan:
+				logger.Info("Received SIGHUP, reloading config")
+				s.reload()
+			}
+		}
+	}()
+}
+
+// watchFile сообщает в changed об изменении файла. Отслеживается каталог, как в viper:
+// редакторы и ConfigMap в Kubernetes заменяют файл или symlink на него, а не пишут в него.
+func watchFile(ctx context.Context, file string, changed chan<- struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	file = filepath.Clean(file)
+	realFile, _ := filepath.EvalSymlinks(file)
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				currentFile, _ := filepath.EvalSymlinks(file)
+				written := filepath.Clean(event.Name) == file && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create))
+				relinked := currentFile != "" && currentFile != realFile
+				if !written && !relinked {
+					continue
+				}
+				realFile = currentFile
+
+				// Несколько событий подряд схлопываются в одно перечитывание
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Error("Config watcher error", zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *Store) reload() {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	if err := viper.ReadInConfig(); err != nil {
+		logger.Error("Failed to read config file", zap.Error(err))
+		return
+	}
+
+	next, err := decode()
+	if err != nil {
+		logger.Error("Failed to reload config", zap.Error(err))
+		return
+	}
+
+	if err := next.Validate(); err != nil {
+		logger.Error("Reloaded config is invalid, keeping current config", zap.Error(err))
+		return
+	}
+
+	applied, restartRequired := s.Update(next)
+	if len(restartRequired) > 0 {
+		logger.Warn("Config changes require a restart and were not applied",
+			zap.Strings("keys", restartRequired))
+	}
+	if len(applied) > 0 {
+		logger.Info("Config reloaded", zap.Strings("keys", applied))
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "errors"
+
+// Базовые виды ошибок. Проверяются через errors.Is и определяют HTTP статус ответа.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrGone        = errors.New("gone")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("unavailable")
+
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrRateLimited     = errors.New("rate limited")
+)
+
+// Error ошибка предметной области со стабильным машиночитаемым кодом
+type Error struct {
+	Kind    error  // один из базовых видов ошибок выше
+	Code    string // стабильный код ошибки, например flight_not_found
+	Message string
+	Err     error // исходная причина, если есть
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func NotFound(code, message string) error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+// Gone ресурс существовал, но удален
+func Gone(code, message string) error {
+	return &Error{Kind: ErrGone, Code: code, Message: message}
+}
+
+func Conflict(code, message string, cause error) error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message, Err: cause}
+}
+
+func Validation(code, message string) error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message}
+}
+
+func Unavailable(code, message string, cause error) error {
+	return &Error{Kind: ErrUnavailable, Code: code, Message: message, Err: cause}
+}
+
+func Unauthenticated(code, message string) error {
+	return &Error{Kind: ErrUnauthenticated, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+func RateLimited(code, message string) error {
+	return &Error{Kind: ErrRateLimited, Code: code, Message: message}
+}
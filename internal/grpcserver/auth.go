@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"flight-service/internal/auth"
+	flightpb "flight-service/pkg/flightpb/v1"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// methodScopes области доступа для методов FlightService. Методы вне списка (health, reflection) открыты.
+var methodScopes = map[string]string{
+	flightpb.FlightService_CreateFlight_FullMethodName:  auth.ScopeFlightsWrite,
+	flightpb.FlightService_GetFlight_FullMethodName:     auth.ScopeFlightsRead,
+	flightpb.FlightService_GetFlightMeta_FullMethodName: auth.ScopeFlightsRead,
+	flightpb.FlightService_SearchFlights_FullMethodName: auth.ScopeFlightsRead,
+	flightpb.FlightService_WatchFlight_FullMethodName:   auth.ScopeFlightsRead,
+}
+
+func authenticate(ctx context.Context, authenticator *auth.Authenticator, fullMethod string) (context.Context, error) {
+	scope, ok := methodScopes[fullMethod]
+	if !ok || !authenticator.Enabled() {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	bearer, _ := strings.CutPrefix(firstValue(md, "authorization"), "Bearer ")
+
+	principal, err := authenticator.Authenticate(ctx, bearer, firstValue(md, "x-api-key"))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := authenticator.Authorize(principal, scope); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func unaryAuthInterceptor(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream подменяет контекст стрима контекстом с Principal
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
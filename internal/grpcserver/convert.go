@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"flight-service/internal/model"
+	flightpb "flight-service/pkg/flightpb/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoFlight(flight *model.FlightData) *flightpb.Flight {
+	return &flightpb.Flight{
+		AircraftType:    flight.AircraftType,
+		FlightNumber:    flight.FlightNumber,
+		DepartureDate:   timestamppb.New(flight.DepartureDate),
+		ArrivalDate:     timestamppb.New(flight.ArrivalDate),
+		PassengersCount: int32(flight.PassengersCount),
+		UpdatedAt:       timestamppb.New(flight.UpdatedAt),
+		Origin:          flight.Origin,
+		Destination:     flight.Destination,
+	}
+}
+
+func toProtoMeta(meta *model.FlightMeta) *flightpb.FlightMeta {
+	result := &flightpb.FlightMeta{
+		Id:            int64(meta.ID),
+		FlightNumber:  meta.FlightNumber,
+		DepartureDate: timestamppb.New(meta.DepartureDate),
+		Status:        meta.Status,
+		CreatedAt:     timestamppb.New(meta.CreatedAt),
+		CreatedBy:     meta.CreatedBy,
+	}
+	if meta.ProcessedAt != nil && !meta.ProcessedAt.IsZero() {
+		result.ProcessedAt = timestamppb.New(*meta.ProcessedAt)
+	}
+	return result
+}
+
+func toProtoPagination(p model.Pagination) *flightpb.Pagination {
+	return &flightpb.Pagination{
+		Total: int32(p.Total),
+		Limit: int32(p.Limit),
+	}
+}
+
+// fromTimestamp возвращает нулевое время для незаполненного поля
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,49 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus переводит ошибки предметной области в gRPC статус, внутренние ошибки не раскрываются
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := "internal server error"
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		message = domainErr.Code + ": " + domainErr.Message
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrGone):
+		return status.Error(codes.NotFound, message)
+	case errors.Is(err, domain.ErrValidation):
+		return status.Error(codes.InvalidArgument, message)
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.AlreadyExists, message)
+	case errors.Is(err, domain.ErrUnavailable):
+		return status.Error(codes.Unavailable, message)
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, message)
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, message)
+	case errors.Is(err, domain.ErrRateLimited):
+		return status.Error(codes.ResourceExhausted, message)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	logger.Error("gRPC request failed", zap.Error(err))
+	return status.Error(codes.Internal, message)
+}
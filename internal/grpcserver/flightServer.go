@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/service"
+	flightpb "flight-service/pkg/flightpb/v1"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type flightServer struct {
+	flightpb.UnimplementedFlightServiceServer
+
+	flightService service.FlightService
+	cfg           *config.Store
+	stopping      <-chan struct{}
+}
+
+func newFlightServer(flightService service.FlightService, cfg *config.Store, stopping <-chan struct{}) *flightServer {
+	return &flightServer{
+		flightService: flightService,
+		cfg:           cfg,
+		stopping:      stopping,
+	}
+}
+
+func (s *flightServer) CreateFlight(ctx context.Context, req *flightpb.CreateFlightRequest) (*flightpb.CreateFlightResponse, error) {
+	metaID, err := s.flightService.CreateFlight(ctx, &model.FlightRequest{
+		AircraftType:    req.GetAircraftType(),
+		FlightNumber:    req.GetFlightNumber(),
+		DepartureDate:   fromTimestamp(req.GetDepartureDate()),
+		ArrivalDate:     fromTimestamp(req.GetArrivalDate()),
+		PassengersCount: int(req.GetPassengersCount()),
+		Origin:          req.GetOrigin(),
+		Destination:     req.GetDestination(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &flightpb.CreateFlightResponse{
+		Id:     int64(metaID),
+		Status: "pending",
+	}, nil
+}
+
+func (s *flightServer) GetFlight(ctx context.Context, req *flightpb.GetFlightRequest) (*flightpb.Flight, error) {
+	if req.GetFlightNumber() == "" || req.GetDepartureDate() == nil {
+		return nil, toStatus(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
+	}
+
+	flight, err := s.flightService.GetFlight(ctx, req.GetFlightNumber(), req.GetDepartureDate().AsTime())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProtoFlight(flight), nil
+}
+
+func (s *flightServer) GetFlightMeta(ctx context.Context, req *flightpb.GetFlightMetaRequest) (*flightpb.GetFlightMetaResponse, error) {
+	if req.GetFlightNumber() == "" {
+		return nil, toStatus(domain.Validation("missing_required_fields", "flight_number is required"))
+	}
+
+	response, err := s.flightService.GetFlightMeta(ctx, req.GetFlightNumber(), req.GetStatus(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	meta := make([]*flightpb.FlightMeta, len(response.Meta))
+	for i, m := range response.Meta {
+		meta[i] = toProtoMeta(m)
+	}
+
+	return &flightpb.GetFlightMetaResponse{
+		FlightNumber: response.FlightNumber,
+		Meta:         meta,
+		Pagination:   toProtoPagination(response.Pagination),
+	}, nil
+}
+
+func (s *flightServer) SearchFlights(ctx context.Context, req *flightpb.SearchFlightsRequest) (*flightpb.SearchFlightsResponse, error) {
+	response, err := s.flightService.SearchFlights(ctx, model.FlightFilter{
+		FlightNumber:  req.GetFlightNumber(),
+		AircraftType:  req.GetAircraftType(),
+		DepartureFrom: fromTimestamp(req.GetDepartureFrom()),
+		DepartureTo:   fromTimestamp(req.GetDepartureTo()),
+		Origin:        req.GetOrigin(),
+		Destination:   req.GetDestination(),
+		Limit:         int(req.GetLimit()),
+		Offset:        int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	flights := make([]*flightpb.Flight, len(response.Flights))
+	for i, flight := range response.Flights {
+		flights[i] = toProtoFlight(flight)
+	}
+
+	return &flightpb.SearchFlightsResponse{
+		Flights:    flights,
+		Pagination: toProtoPagination(response.Pagination),
+	}, nil
+}
+
+// WatchFlight опрашивает рейс с периодом grpc.watch_interval и отправляет его при каждом изменении updated_at.
+// Если рейса еще нет, стрим ждет его появления.
+func (s *flightServer) WatchFlight(req *flightpb.WatchFlightRequest, stream grpc.ServerStreamingServer[flightpb.Flight]) error {
+	if req.GetFlightNumber() == "" || req.GetDepartureDate() == nil {
+		return toStatus(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
+	}
+
+	ctx := stream.Context()
+	departureDate := req.GetDepartureDate().AsTime()
+	var lastUpdatedAt time.Time
+
+	for {
+		flight, err := s.flightService.GetFlight(ctx, req.GetFlightNumber(), departureDate)
+		switch {
+		case err == nil && !flight.UpdatedAt.Equal(lastUpdatedAt):
+			if err := stream.Send(toProtoFlight(flight)); err != nil {
+				return err
+			}
+			lastUpdatedAt = flight.UpdatedAt
+		case err != nil && !errors.Is(err, domain.ErrNotFound):
+			return toStatus(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stopping:
+			return nil
+		case <-time.After(s.cfg.Current().GRPC.WatchInterval):
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package grpcserver
+
+import (
+	"context"
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
+	"flight-service/internal/ratelimit"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// rateLimit проверяет лимит для методов FlightService и выставляет retry-after в трейлер ответа
+func rateLimit(ctx context.Context, limiter ratelimit.Limiter, cfg *config.Store, fullMethod string) error {
+	if _, ok := methodScopes[fullMethod]; !ok {
+		return nil
+	}
+
+	retryAfter, err := ratelimit.Check(ctx, limiter, cfg.Current().RateLimit, clientID(ctx), fullMethod)
+	if err != nil {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", ratelimit.RetryAfterSeconds(retryAfter)))
+		return toStatus(err)
+	}
+	return nil
+}
+
+// ipRateLimit проверяет лимит на IP адрес клиента до аутентификации
+func ipRateLimit(ctx context.Context, limiter ratelimit.Limiter, cfg *config.Store, fullMethod string) error {
+	if _, ok := methodScopes[fullMethod]; !ok {
+		return nil
+	}
+
+	retryAfter, err := ratelimit.CheckIP(ctx, limiter, cfg.Current().RateLimit, peerIP(ctx))
+	if err != nil {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", ratelimit.RetryAfterSeconds(retryAfter)))
+		return toStatus(err)
+	}
+	return nil
+}
+
+func clientID(ctx context.Context) string {
+	if id := auth.CallerID(ctx); id != "" {
+		return id
+	}
+	return peerIP(ctx)
+}
+
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func unaryIPRateLimitInterceptor(limiter ratelimit.Limiter, cfg *config.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := ipRateLimit(ctx, limiter, cfg, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamIPRateLimitInterceptor(limiter ratelimit.Limiter, cfg *config.Store) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := ipRateLimit(ss.Context(), limiter, cfg, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func unaryRateLimitInterceptor(limiter ratelimit.Limiter, cfg *config.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := rateLimit(ctx, limiter, cfg, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamRateLimitInterceptor(limiter ratelimit.Limiter, cfg *config.Store) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rateLimit(ss.Context(), limiter, cfg, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
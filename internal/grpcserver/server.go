@@ -0,0 +1,109 @@
+package grpcserver
+
+import (
+	"context"
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
+	"flight-service/internal/metrics"
+	"flight-service/internal/ratelimit"
+	"flight-service/internal/service"
+	flightpb "flight-service/pkg/flightpb/v1"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Server gRPC сервер с FlightService, health и reflection
+type Server struct {
+	addr     string
+	server   *grpc.Server
+	health   *health.Server
+	stopping chan struct{}
+}
+
+func NewServer(addr string, flightService service.FlightService, cfg *config.Store, authenticator *auth.Authenticator, limiter ratelimit.Limiter) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryMetricsInterceptor, unaryIPRateLimitInterceptor(limiter, cfg),
+			unaryAuthInterceptor(authenticator), unaryRateLimitInterceptor(limiter, cfg)),
+		grpc.ChainStreamInterceptor(streamMetricsInterceptor, streamIPRateLimitInterceptor(limiter, cfg),
+			streamAuthInterceptor(authenticator), streamRateLimitInterceptor(limiter, cfg)),
+	)
+
+	healthServer := health.NewServer()
+	stopping := make(chan struct{})
+
+	flightpb.RegisterFlightServiceServer(grpcServer, newFlightServer(flightService, cfg, stopping))
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	healthServer.SetServingStatus(flightpb.FlightService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	return &Server{
+		addr:     addr,
+		server:   grpcServer,
+		health:   healthServer,
+		stopping: stopping,
+	}
+}
+
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// ListenAndServe слушает адрес и обслуживает запросы до остановки сервера
+func (s *Server) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	return s.server.Serve(lis)
+}
+
+// Shutdown переводит health в NOT_SERVING, завершает стримы WatchFlight и дожидается
+// завершения активных вызовов, при истечении ctx обрывает оставшиеся соединения
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	close(s.stopping)
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func unaryMetricsInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	metrics.GrpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	metrics.GrpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+func streamMetricsInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	metrics.GrpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	metrics.GrpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+	return err
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type AdminHandler struct {
+	logLevel zap.AtomicLevel
+	cfg      *config.Store
+}
+
+func NewAdminHandler(logLevel zap.AtomicLevel, cfg *config.Store) *AdminHandler {
+	return &AdminHandler{
+		logLevel: logLevel,
+		cfg:      cfg,
+	}
+}
+
+// GetLogLevelHandler обрабатывает GET запрос на /admin/loglevel
+func (h *AdminHandler) GetLogLevelHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, model.LogLevel{Level: h.logLevel.Level().String()})
+}
+
+// SetLogLevelHandler обрабатывает PUT запрос на /admin/loglevel и меняет уровень логирования без перезапуска
+func (h *AdminHandler) SetLogLevelHandler(c *gin.Context) {
+	var req model.LogLevel
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	var level zapcore.Level
+	if err := level.Set(req.Level); err != nil {
+		c.Error(domain.Validation("invalid_log_level", "unknown log level: "+req.Level))
+		return
+	}
+
+	// Уровень меняется через Store, чтобы logger.level в конфиге совпадал с фактическим
+	// и перечитывание файла не вернуло прежний уровень
+	previous := h.logLevel.Level()
+	h.cfg.SetLogLevel(level.String())
+	h.logLevel.SetLevel(level)
+
+	logger.Info("Log level changed",
+		zap.String("from", previous.String()),
+		zap.String("to", level.String()))
+
+	c.JSON(http.StatusOK, model.LogLevel{Level: level.String()})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteFlightHandler обрабатывает DELETE запрос на /api/flights
+func (h *FlightHandler) DeleteFlightHandler(c *gin.Context) {
+	flightNumber := c.Query("flight_number")
+	departureDateString := c.Query("departure_date")
+
+	if flightNumber == "" || departureDateString == "" {
+		c.Error(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
+		return
+	}
+
+	departureDate, err := time.Parse(time.RFC3339, departureDateString)
+	if err != nil {
+		c.Error(domain.Validation("invalid_departure_date", "invalid departure_date format, expected RFC3339"))
+		return
+	}
+
+	h.respondQueued(c, h.flightService.DeleteFlight, flightNumber, departureDate)
+}
+
+// CancelFlightHandler обрабатывает POST запрос на /api/flights/cancel
+func (h *FlightHandler) CancelFlightHandler(c *gin.Context) {
+	var req model.FlightRefRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	h.respondQueued(c, h.flightService.CancelFlight, req.FlightNumber, req.DepartureDate)
+}
+
+// RestoreFlightHandler обрабатывает POST запрос на /api/flights/restore
+func (h *FlightHandler) RestoreFlightHandler(c *gin.Context) {
+	var req model.FlightRefRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	h.respondQueued(c, h.flightService.RestoreFlight, req.FlightNumber, req.DepartureDate)
+}
+
+// respondQueued ставит операцию над рейсом в очередь и отвечает так же, как POST /api/flights
+func (h *FlightHandler) respondQueued(c *gin.Context, operation func(context.Context, string, time.Time) (int, error),
+	flightNumber string, departureDate time.Time) {
+	metaID, err := operation(c.Request.Context(), flightNumber, departureDate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.CreateFlightResponse{
+		ID:     metaID,
+		Status: "pending",
+	})
+}
@@ -1,10 +1,9 @@
 package handlers
 
 import (
-	"flight-service/internal/logger"
+	"flight-service/internal/domain"
 	"flight-service/internal/model"
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 	"net/http"
 )
 
@@ -14,27 +13,42 @@ func (h *FlightHandler) CreateFlightHandler(c *gin.Context) {
 
 	// Декодируем JSON из тела запроса
 	if err := c.ShouldBindJSON(&flightReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
 		return
 	}
 
-	// Валидация обязательных полей
-	if flightReq.FlightNumber == "" || flightReq.DepartureDate.IsZero() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "flight_number and departure_date are required"})
+	// Используем сервис для создания полета, валидация полей выполняется в сервисе
+	metaID, err := h.flightService.CreateFlight(c.Request.Context(), &flightReq)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	// Используем сервис для создания полета
-	metaID, err := h.flightService.CreateFlight(c.Request.Context(), &flightReq)
+	// Возвращаем ответ
+	c.JSON(http.StatusOK, model.CreateFlightResponse{
+		ID:     metaID,
+		Status: "pending",
+	})
+}
+
+// PatchFlightHandler обрабатывает PATCH запрос на /api/flights
+func (h *FlightHandler) PatchFlightHandler(c *gin.Context) {
+	var patchReq model.FlightPatchRequest
+
+	if err := c.ShouldBindJSON(&patchReq); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	// Маска и поля проверяются в сервисе
+	metaID, err := h.flightService.PatchFlight(c.Request.Context(), &patchReq)
 	if err != nil {
-		logger.Error("Failed to create flight", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create flight record"})
+		c.Error(err)
 		return
 	}
 
-	// Возвращаем ответ
-	c.JSON(http.StatusOK, gin.H{
-		"id":     metaID,
-		"status": "pending",
+	c.JSON(http.StatusOK, model.CreateFlightResponse{
+		ID:     metaID,
+		Status: "pending",
 	})
 }
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangeFlightStatusHandler обрабатывает POST запрос на /api/flights/status
+func (h *FlightHandler) ChangeFlightStatusHandler(c *gin.Context) {
+	var req model.FlightStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	metaID, err := h.flightService.ChangeFlightStatus(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.CreateFlightResponse{
+		ID:     metaID,
+		Status: "pending",
+	})
+}
+
+// GetFlightStatusHandler обрабатывает GET запрос на /api/flights/status
+func (h *FlightHandler) GetFlightStatusHandler(c *gin.Context) {
+	flightNumber := c.Query("flight_number")
+	departureDateString := c.Query("departure_date")
+
+	if flightNumber == "" || departureDateString == "" {
+		c.Error(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
+		return
+	}
+
+	departureDate, err := time.Parse(time.RFC3339, departureDateString)
+	if err != nil {
+		c.Error(domain.Validation("invalid_departure_date", "invalid departure_date format, expected RFC3339"))
+		return
+	}
+
+	response, err := h.flightService.GetFlightStatus(c.Request.Context(), flightNumber, departureDate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewFlightStatusListResponse(response))
+}
@@ -2,13 +2,12 @@ package handlers
 
 import (
 	"net/http"
-	"strings"
 	"time"
 
-	"flight-service/internal/logger"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 )
 
 // GetFlightHandler обрабатывает GET запрос на /api/flights
@@ -18,38 +17,35 @@ func (h *FlightHandler) GetFlightHandler(c *gin.Context) {
 	departureDateString := c.Query("departure_date")
 
 	if flightNumber == "" || departureDateString == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "flight_number and departure_date are required"})
+		c.Error(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
 		return
 	}
 
 	// Преобразование строки даты в time.Time
 	departureDate, err := time.Parse(time.RFC3339, departureDateString)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid departure_date format, expected RFC3339"})
+		c.Error(domain.Validation("invalid_departure_date", "invalid departure_date format, expected RFC3339"))
 		return
 	}
 
-	// Используем сервис для получения полета
-	flight, err := h.flightService.GetFlight(c.Request.Context(), flightNumber, departureDate)
-	if err != nil {
-		logger.Error("Failed to get flight", zap.Error(err))
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	// as_of - состояние рейса, известное сервису в указанный момент
+	var flight *model.FlightData
+	if asOfString := c.Query("as_of"); asOfString != "" {
+		asOf, parseErr := time.Parse(time.RFC3339, asOfString)
+		if parseErr != nil {
+			c.Error(domain.Validation("invalid_as_of", "invalid as_of format, expected RFC3339"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get flight"})
+		flight, err = h.flightService.GetFlightAsOf(c.Request.Context(), flightNumber, departureDate, asOf)
+	} else {
+		// Используем сервис для получения полета
+		flight, err = h.flightService.GetFlight(c.Request.Context(), flightNumber, departureDate)
+	}
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Возврат ВСЕХ данных рейса согласно FlightData
-	response := gin.H{
-		"aircraft_type":    flight.AircraftType,
-		"flight_number":    flight.FlightNumber,
-		"departure_date":   flight.DepartureDate.Format(time.RFC3339),
-		"arrival_date":     flight.ArrivalDate.Format(time.RFC3339),
-		"passengers_count": flight.PassengersCount,
-		"updated_at":       flight.UpdatedAt.Format(time.RFC3339),
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, model.NewFlightResponse(flight))
 }
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFlightHistoryHandler обрабатывает GET запрос на /api/flights/history
+func (h *FlightHandler) GetFlightHistoryHandler(c *gin.Context) {
+	flightNumber := c.Query("flight_number")
+	departureDateString := c.Query("departure_date")
+
+	if flightNumber == "" || departureDateString == "" {
+		c.Error(domain.Validation("missing_required_fields", "flight_number and departure_date are required"))
+		return
+	}
+
+	departureDate, err := time.Parse(time.RFC3339, departureDateString)
+	if err != nil {
+		c.Error(domain.Validation("invalid_departure_date", "invalid departure_date format, expected RFC3339"))
+		return
+	}
+
+	// Лимиты выдачи общие с историей обработки (meta)
+	metaCfg := h.cfg.Current().Meta
+	limit := metaCfg.DefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 || parsedLimit > metaCfg.MaxLimit {
+			c.Error(domain.Validation("invalid_limit", fmt.Sprintf("limit must be a positive integer not exceeding %d", metaCfg.MaxLimit)))
+			return
+		}
+		limit = parsedLimit
+	}
+
+	response, err := h.flightService.GetFlightHistory(c.Request.Context(), flightNumber, departureDate, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewFlightHistoryListResponse(response))
+}
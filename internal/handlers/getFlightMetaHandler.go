@@ -1,21 +1,21 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
-	"flight-service/internal/logger"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
 
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 )
 
 func (h *FlightHandler) GetFlightMetaHandler(c *gin.Context) {
 	// Извлечение параметра flight_number из пути
 	flightNumber := c.Param("flight_number")
 	if flightNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "flight_number is required"})
+		c.Error(domain.Validation("missing_required_fields", "flight_number is required"))
 		return
 	}
 
@@ -24,11 +24,12 @@ func (h *FlightHandler) GetFlightMetaHandler(c *gin.Context) {
 	limitStr := c.Query("limit")
 
 	// Установка значений по умолчанию
-	limit := 50
+	metaCfg := h.cfg.Current().Meta
+	limit := metaCfg.DefaultLimit
 	if limitStr != "" {
 		parsedLimit, err := strconv.Atoi(limitStr)
-		if err != nil || parsedLimit <= 0 || parsedLimit > 100 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer not exceeding 100"})
+		if err != nil || parsedLimit <= 0 || parsedLimit > metaCfg.MaxLimit {
+			c.Error(domain.Validation("invalid_limit", fmt.Sprintf("limit must be a positive integer not exceeding %d", metaCfg.MaxLimit)))
 			return
 		}
 		limit = parsedLimit
@@ -37,35 +38,9 @@ func (h *FlightHandler) GetFlightMetaHandler(c *gin.Context) {
 	// Используем сервис для получения метаданных
 	response, err := h.flightService.GetFlightMeta(c.Request.Context(), flightNumber, status, limit)
 	if err != nil {
-		logger.Error("Failed to get flight meta", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get flight meta"})
+		c.Error(err)
 		return
 	}
 
-	// Формирование ответа
-	metaList := make([]gin.H, len(response.Meta))
-	for i, meta := range response.Meta {
-		processedAt := ""
-		if meta.ProcessedAt != nil && !meta.ProcessedAt.IsZero() {
-			processedAt = meta.ProcessedAt.Format(time.RFC3339)
-		}
-
-		metaList[i] = gin.H{
-			"id":             meta.ID,
-			"flight_number":  meta.FlightNumber,
-			"departure_date": meta.DepartureDate.Format(time.RFC3339),
-			"status":         meta.Status,
-			"created_at":     meta.CreatedAt.Format(time.RFC3339),
-			"processed_at":   processedAt,
-		}
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"flight_number": response.FlightNumber,
-		"meta":          metaList,
-		"pagination": gin.H{
-			"total": response.Pagination.Total,
-			"limit": response.Pagination.Limit,
-		},
-	})
+	c.JSON(http.StatusOK, model.NewFlightMetaListResponse(response))
 }
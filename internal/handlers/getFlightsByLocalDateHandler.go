@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFlightsByLocalDateHandler обрабатывает GET запрос на /api/flights/:flight_number/:date
+func (h *FlightHandler) GetFlightsByLocalDateHandler(c *gin.Context) {
+	flightNumber := c.Param("flight_number")
+
+	// Дата без времени: сутки считаются по местному времени аэропорта вылета
+	date, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		c.Error(domain.Validation("invalid_date", "invalid date format, expected YYYY-MM-DD"))
+		return
+	}
+
+	response, err := h.flightService.GetFlightsByLocalDate(c.Request.Context(), flightNumber, date)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewFlightDayListResponse(response))
+}
@@ -2,17 +2,20 @@ package handlers
 
 import (
 	"context"
+	"flight-service/internal/config"
 	"flight-service/internal/model"
 	"flight-service/internal/service"
 )
 
 type FlightHandler struct {
 	flightService service.FlightService
+	cfg           *config.Store
 }
 
-func NewFlightHandler(flightService service.FlightService) *FlightHandler {
+func NewFlightHandler(flightService service.FlightService, cfg *config.Store) *FlightHandler {
 	return &FlightHandler{
 		flightService: flightService,
+		cfg:           cfg,
 	}
 }
 
@@ -1,23 +1,60 @@
 package routes
 
 import (
+	"flight-service/api"
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
 	"flight-service/internal/handlers"
 	"flight-service/internal/middleware"
+	"flight-service/internal/ratelimit"
 	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
 // SetupRoutes настраивает маршруты для обработчика
-func SetupRoutes(handler *handlers.FlightHandler) *gin.Engine {
+func SetupRoutes(handler *handlers.FlightHandler, webhookHandler *handlers.WebhookHandler, streamHandler *handlers.StreamHandler, adminHandler *handlers.AdminHandler,
+	authenticator *auth.Authenticator, limiter ratelimit.Limiter, cfg *config.Store) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	r := gin.New()
 
 	r.Use(gin.Recovery())
 	r.Use(middleware.MetricsMiddleware())
+	r.Use(middleware.ErrorMiddleware())
+	r.Use(middleware.IPRateLimitMiddleware(limiter, cfg)) // до аутентификации
 
-	r.POST("/api/flights", handler.CreateFlightHandler)
-	r.GET("/api/flights", handler.GetFlightHandler)
-	r.GET("/api/flights/:flight_number/meta", handler.GetFlightMetaHandler)
+	canRead := middleware.AuthMiddleware(authenticator, auth.ScopeFlightsRead)
+	canWrite := middleware.AuthMiddleware(authenticator, auth.ScopeFlightsWrite)
+	limited := middleware.RateLimitMiddleware(limiter, cfg)
+
+	r.POST("/api/flights", canWrite, limited, handler.CreateFlightHandler)
+	r.PATCH("/api/flights", canWrite, limited, handler.PatchFlightHandler)
+	r.GET("/api/flights", canRead, limited, handler.GetFlightHandler)
+	r.DELETE("/api/flights", canWrite, limited, handler.DeleteFlightHandler)
+	r.POST("/api/flights/cancel", canWrite, limited, handler.CancelFlightHandler)
+	r.POST("/api/flights/restore", canWrite, limited, handler.RestoreFlightHandler)
+	r.GET("/api/flights/history", canRead, limited, handler.GetFlightHistoryHandler)
+	r.POST("/api/flights/status", canWrite, limited, handler.ChangeFlightStatusHandler)
+	r.GET("/api/flights/status", canRead, limited, handler.GetFlightStatusHandler)
+	r.GET("/api/flights/stream", canRead, limited, streamHandler.StreamFlightsHandler)
+	r.GET("/api/flights/:flight_number/meta", canRead, limited, handler.GetFlightMetaHandler)
+	r.GET("/api/flights/:flight_number/:date", canRead, limited, handler.GetFlightsByLocalDateHandler)
+
+	webhooks := r.Group("/api/webhooks", middleware.AuthMiddleware(authenticator, auth.ScopeWebhooks), limited)
+	webhooks.POST("", webhookHandler.CreateWebhookHandler)
+	webhooks.GET("", webhookHandler.ListWebhooksHandler)
+	webhooks.GET("/:id", webhookHandler.GetWebhookHandler)
+	webhooks.PUT("/:id", webhookHandler.UpdateWebhookHandler)
+	webhooks.DELETE("/:id", webhookHandler.DeleteWebhookHandler)
+	webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveriesHandler)
+
+	admin := r.Group("/admin", middleware.AuthMiddleware(authenticator, auth.ScopeAdmin))
+	admin.GET("/loglevel", adminHandler.GetLogLevelHandler)
+	admin.PUT("/loglevel", adminHandler.SetLogLevelHandler)
+
+	r.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", api.Spec)
+	})
 
 	return r
 }
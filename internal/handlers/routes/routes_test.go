@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flight-service/api"
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
+	"flight-service/internal/handlers"
+	"flight-service/internal/logger"
+	"flight-service/internal/ratelimit"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Маршруты сервера и пути спецификации OpenAPI должны совпадать
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	r := SetupRoutes(&handlers.FlightHandler{}, &handlers.WebhookHandler{}, &handlers.StreamHandler{},
+		handlers.NewAdminHandler(zap.NewAtomicLevel(), nil), nil, nil, nil)
+
+	if err := api.VerifyRoutes(r.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Лимит на IP срабатывает раньше аутентификации, поток запросов без ключа получает 429
+func TestIPRateLimitBeforeAuth(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	authenticator, err := auth.NewAuthenticator(config.AuthConfig{Enabled: true}, nil)
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	cfg := config.NewStore(&config.Config{RateLimit: config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitRule{Rate: 100, Burst: 100},
+		PerIP:   config.RateLimitRule{Rate: 0.001, Burst: 2},
+	}})
+
+	r := SetupRoutes(&handlers.FlightHandler{}, &handlers.WebhookHandler{}, &handlers.StreamHandler{},
+		handlers.NewAdminHandler(zap.NewAtomicLevel(), cfg), authenticator, ratelimit.NewMemoryLimiter(), cfg)
+
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	for i, status := range want {
+		req := httptest.NewRequest(http.MethodGet, "/api/flights?flight_number=SU100", nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != status {
+			t.Fatalf("request %d status = %d, want %d", i+1, w.Code, status)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreamHandler struct {
+	streamService service.FlightStreamService // nil, если поток выключен
+	cfg           *config.Store
+}
+
+func NewStreamHandler(streamService service.FlightStreamService, cfg *config.Store) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+		cfg:           cfg,
+	}
+}
+
+// StreamFlightsHandler обрабатывает GET запрос на /api/flights/stream и отдает
+// обновления рейсов как Server-Sent Events
+func (h *StreamHandler) StreamFlightsHandler(c *gin.Context) {
+	if h.streamService == nil {
+		c.Error(domain.Unavailable("stream_disabled", "flight stream is disabled", nil))
+		return
+	}
+
+	filter, err := streamFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	lastEventID, err := lastEventID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы не потерять события между ними
+	events, unsubscribe, err := h.streamService.Subscribe(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer unsubscribe()
+
+	var replay []*model.StreamEvent
+	complete := true
+	if lastEventID > 0 {
+		replay, complete, err = h.streamService.Replay(c.Request.Context(), filter, lastEventID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+
+	// Пропуск больше replay_limit не досылается: клиент загружает рейсы заново
+	// и продолжает с событий, пришедших после reset
+	if !complete {
+		if _, err := io.WriteString(c.Writer, streamResetEvent); err != nil {
+			return
+		}
+	}
+
+	replayed := make(map[int64]bool, len(replay))
+	for _, event := range replay {
+		replayed[event.ID] = true
+		if err := writeStreamEvent(c.Writer, event); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.cfg.Current().Stream.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			// Канал закрыт: клиент не успевал читать или сервис останавливается.
+			// Клиент переподключится и продолжит с Last-Event-ID.
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if err := writeStreamEvent(c.Writer, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			// Комментарий не дает прокси закрыть простаивающее соединение
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// streamResetEvent отправляется вместо досылки, когда клиент отстал больше чем на stream.replay_limit
+const streamResetEvent = "event: reset\ndata: {\"reason\":\"replay_limit_exceeded\"}\n\n"
+
+func writeStreamEvent(w io.Writer, event *model.StreamEvent) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+	return err
+}
+
+func streamFilter(c *gin.Context) (model.StreamFilter, error) {
+	filter := model.StreamFilter{
+		FlightNumber: c.Query("flight_number"),
+		AircraftType: c.Query("aircraft_type"),
+	}
+
+	var err error
+	if value := c.Query("departure_from"); value != "" {
+		if filter.DepartureFrom, err = time.Parse(time.RFC3339, value); err != nil {
+			return filter, domain.Validation("invalid_departure_from", "invalid departure_from format, expected RFC3339")
+		}
+	}
+	if value := c.Query("departure_to"); value != "" {
+		if filter.DepartureTo, err = time.Parse(time.RFC3339, value); err != nil {
+			return filter, domain.Validation("invalid_departure_to", "invalid departure_to format, expected RFC3339")
+		}
+	}
+	if !filter.DepartureFrom.IsZero() && !filter.DepartureTo.IsZero() && !filter.DepartureFrom.Before(filter.DepartureTo) {
+		return filter, domain.Validation("invalid_departure_range", "departure_from must be before departure_to")
+	}
+
+	return filter, nil
+}
+
+// lastEventID читает заголовок Last-Event-ID, который браузер отправляет при переподключении.
+// Параметр last_event_id нужен для первого подключения, когда заголовок задать нельзя.
+func lastEventID(c *gin.Context) (int64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, domain.Validation("invalid_last_event_id", "Last-Event-ID must be a non-negative integer")
+	}
+	return id, nil
+}
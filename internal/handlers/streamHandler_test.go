@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flight-service/internal/config"
+	"flight-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeStreamService отдает заданную досылку и закрытый канал, чтобы обработчик завершился
+type fakeStreamService struct {
+	replay   []*model.StreamEvent
+	complete bool
+}
+
+func (s *fakeStreamService) Subscribe(model.StreamFilter) (<-chan *model.StreamEvent, func(), error) {
+	events := make(chan *model.StreamEvent)
+	close(events)
+	return events, func() {}, nil
+}
+
+func (s *fakeStreamService) Replay(context.Context, model.StreamFilter, int64) ([]*model.StreamEvent, bool, error) {
+	return s.replay, s.complete, nil
+}
+
+func TestStreamFlightsHandlerReplay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		service *fakeStreamService
+		want    string
+	}{
+		{
+			name: "replay within limit",
+			service: &fakeStreamService{
+				replay:   []*model.StreamEvent{{ID: 6, Type: "flight.updated", Payload: []byte(`{}`)}},
+				complete: true,
+			},
+			want: "id: 6\nevent: flight.updated\ndata: {}\n\n",
+		},
+		{
+			name:    "replay limit exceeded",
+			service: &fakeStreamService{complete: false},
+			want:    "event: reset\ndata: {\"reason\":\"replay_limit_exceeded\"}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewStreamHandler(tt.service, config.NewStore(&config.Config{
+				Stream: config.StreamConfig{HeartbeatInterval: time.Minute},
+			}))
+			r := gin.New()
+			r.GET("/api/flights/stream", handler.StreamFlightsHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/flights/stream", nil)
+			req.Header.Set("Last-Event-ID", "5")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if body := w.Body.String(); body != tt.want {
+				t.Errorf("body = %q, want %q", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const deliveriesLimit = 100
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhookHandler обрабатывает POST запрос на /api/webhooks
+func (h *WebhookHandler) CreateWebhookHandler(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	sub, err := h.webhookService.CreateWebhook(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Секрет показывается только при создании
+	response := model.NewWebhookResponse(sub)
+	response.Secret = sub.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListWebhooksHandler обрабатывает GET запрос на /api/webhooks
+func (h *WebhookHandler) ListWebhooksHandler(c *gin.Context) {
+	subs, err := h.webhookService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewWebhookListResponse(subs))
+}
+
+// GetWebhookHandler обрабатывает GET запрос на /api/webhooks/:id
+func (h *WebhookHandler) GetWebhookHandler(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.webhookService.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewWebhookResponse(sub))
+}
+
+// UpdateWebhookHandler обрабатывает PUT запрос на /api/webhooks/:id
+func (h *WebhookHandler) UpdateWebhookHandler(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.Validation("invalid_json", "Invalid JSON: "+err.Error()))
+		return
+	}
+
+	sub, err := h.webhookService.UpdateWebhook(c.Request.Context(), id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewWebhookResponse(sub))
+}
+
+// DeleteWebhookHandler обрабатывает DELETE запрос на /api/webhooks/:id
+func (h *WebhookHandler) DeleteWebhookHandler(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveriesHandler обрабатывает GET запрос на /api/webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveriesHandler(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), id, deliveriesLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewWebhookDeliveryListResponse(deliveries))
+}
+
+func webhookID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.Error(domain.Validation("invalid_webhook_id", "id must be a positive integer"))
+		return 0, false
+	}
+	return id, true
+}
@@ -8,6 +8,7 @@ import (
 	"flight-service/internal/model"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -21,14 +22,23 @@ type MessageHandler interface {
 
 type Consumer struct {
 	consumerGroup sarama.ConsumerGroup
+	groupID       string
 	topic         string
 	handler       MessageHandler
+	retryMu       sync.RWMutex
 	retryAttempts int
 	retryDelay    time.Duration
+
+	// txnProducer задан в транзакционном режиме: offset и исходящие события обработчика
+	// (EmitInTransaction) коммитятся одной Kafka транзакцией.
+	txnProducer sarama.AsyncProducer
+	txnWg       sync.WaitGroup
 }
 
-// NewConsumer создаёт новый экземпляр Consumer
-func NewConsumer(brokers []string, groupID, topic string, handler MessageHandler) (*Consumer, error) {
+// NewConsumer создаёт новый экземпляр Consumer. Непустой transactionalID включает
+// транзакционный режим exactly-once.
+func NewConsumer(brokers []string, groupID, topic string, handler MessageHandler, retryAttempts int, retryDelay time.Duration,
+	transactionalID string) (*Consumer, error) {
 	if groupID == "" {
 		return nil, fmt.Errorf("groupID cannot be empty")
 	}
@@ -44,18 +54,81 @@ func NewConsumer(brokers []string, groupID, topic string, handler MessageHandler
 
 	config.Metadata.AllowAutoTopicCreation = true
 
+	c := &Consumer{
+		groupID:       groupID,
+		topic:         topic,
+		handler:       handler,
+		retryAttempts: retryAttempts,
+		retryDelay:    retryDelay,
+	}
+
+	if transactionalID != "" {
+		// Offset коммитится только через транзакцию, незакоммиченные сообщения других продюсеров не читаем
+		config.Version = sarama.V2_1_0_0
+		config.Consumer.IsolationLevel = sarama.ReadCommitted
+		config.Consumer.Offsets.AutoCommit.Enable = false
+
+		txnProducer, err := newTxnProducer(brokers, transactionalID)
+		if err != nil {
+			return nil, err
+		}
+		c.txnProducer = txnProducer
+
+		c.txnWg.Add(1)
+		go c.handleTxnErrors()
+	}
+
 	consumerGroup, err := sarama.NewConsumerGroup(brokers, groupID, config)
 	if err != nil {
+		if c.txnProducer != nil {
+			_ = c.txnProducer.Close()
+		}
 		return nil, fmt.Errorf("не удалось создать ConsumerGroup: %w", err)
 	}
+	c.consumerGroup = consumerGroup
 
-	return &Consumer{
-		consumerGroup: consumerGroup,
-		topic:         topic,
-		handler:       handler,
-		retryAttempts: 3,
-		retryDelay:    5 * time.Second,
-	}, nil
+	return c, nil
+}
+
+func newTxnProducer(brokers []string, transactionalID string) (sarama.AsyncProducer, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_1_0_0
+	config.Producer.Idempotent = true
+	config.Producer.Transaction.ID = transactionalID
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Errors = true
+	config.Producer.Partitioner = sarama.NewHashPartitioner // события одного рейса попадают в одну партицию
+	config.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewAsyncProducer(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать транзакционный producer: %w", err)
+	}
+	return producer, nil
+}
+
+// handleTxnErrors логирует ошибки транзакционного producer; транзакция при этом
+// становится abortable и откатывается в abortTransaction
+func (c *Consumer) handleTxnErrors() {
+	defer c.txnWg.Done()
+
+	for producerErr := range c.txnProducer.Errors() {
+		logger.Error("Failed to produce message in Kafka transaction", zap.Error(producerErr.Err))
+	}
+}
+
+// SetRetryPolicy меняет количество попыток и задержку между ними без перезапуска consumer
+func (c *Consumer) SetRetryPolicy(retryAttempts int, retryDelay time.Duration) {
+	c.retryMu.Lock()
+	defer c.retryMu.Unlock()
+	c.retryAttempts = retryAttempts
+	c.retryDelay = retryDelay
+}
+
+func (c *Consumer) retryPolicy() (int, time.Duration) {
+	c.retryMu.RLock()
+	defer c.retryMu.RUnlock()
+	return c.retryAttempts, c.retryDelay
 }
 
 // Consume запускает потребление сообщений
@@ -84,7 +157,14 @@ func (c *Consumer) Consume(ctx context.Context) error {
 
 // Close закрывает соединение с Kafka
 func (c *Consumer) CloseConsume() error {
-	return c.consumerGroup.Close()
+	err := c.consumerGroup.Close()
+
+	if c.txnProducer != nil {
+		c.txnProducer.AsyncClose()
+		c.txnWg.Wait()
+	}
+
+	return err
 }
 
 // Setup вызывается при инициализации сессии
@@ -108,22 +188,22 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 			continue
 		}
 
-		if message.Key == nil {
-			logger.Error("Message key is nil, skipping")
-			metrics.KafkaProcessingErrors.Inc()
-			continue
-		}
-
-		// Извлечение metaID из ключа сообщения
-		metaID, err := strconv.Atoi(string(message.Key))
+		metaID, err := messageMetaID(message)
 		if err != nil {
-			logger.Error("Failed to parse message key as integer",
+			logger.Error("Failed to get meta id of message",
 				zap.ByteString("key", message.Key),
 				zap.Error(err))
 			metrics.KafkaProcessingErrors.Inc()
 			continue
 		}
 
+		if c.txnProducer != nil {
+			if err := c.consumeInTransaction(session, message, metaID, &request); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Обработка сообщения с retry логикой
 		err = c.processWithRetry(session.Context(), metaID, &request)
 		if err != nil {
@@ -141,16 +221,31 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 	return nil
 }
 
+// messageMetaID извлекает id meta из заголовка meta_id. Сообщения, отправленные до появления
+// заголовка, несут id в ключе.
+func messageMetaID(message *sarama.ConsumerMessage) (int, error) {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == MetaIDHeader {
+			return strconv.Atoi(string(header.Value))
+		}
+	}
+	if message.Key == nil {
+		return 0, fmt.Errorf("message has no %s header and no key", MetaIDHeader)
+	}
+	return strconv.Atoi(string(message.Key))
+}
+
 // processWithRetry выполняет обработку сообщения с retry логикой
 func (c *Consumer) processWithRetry(ctx context.Context, metaID int, request *model.FlightRequest) error {
 	var lastErr error
+	retryAttempts, retryDelay := c.retryPolicy()
 
-	for attempt := 0; attempt < c.retryAttempts; attempt++ {
+	for attempt := 0; attempt < retryAttempts; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(c.retryDelay):
+			case <-time.After(retryDelay):
 			}
 		}
 
@@ -167,3 +262,51 @@ func (c *Consumer) processWithRetry(ctx context.Context, metaID int, request *mo
 
 	return lastErr
 }
+
+// consumeInTransaction обрабатывает сообщение и коммитит его offset в Kafka транзакции вместе
+// с событиями, отправленными через EmitInTransaction. Запись в БД дедуплицируется обработчиком
+// по TransactionOffset, поэтому повторная доставка после неудачного коммита безопасна.
+func (c *Consumer) consumeInTransaction(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, metaID int, request *model.FlightRequest) error {
+	if err := c.txnProducer.BeginTxn(); err != nil {
+		return fmt.Errorf("failed to begin kafka transaction: %w", err)
+	}
+
+	ctx := withTransaction(session.Context(), c.groupID, message, c.txnProducer)
+	if err := c.processWithRetry(ctx, metaID, request); err != nil {
+		// Как и в обычном режиме, сообщение пропускается
+		logger.Error("Ошибка при обработке сообщения после всех попыток", zap.Error(err))
+		metrics.KafkaProcessingErrors.Inc()
+		if err := c.txnProducer.AbortTxn(); err != nil {
+			return fmt.Errorf("failed to abort kafka transaction: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.txnProducer.AddMessageToTxn(message, c.groupID, nil); err != nil {
+		return c.abortTransaction(session, message, fmt.Errorf("failed to add offset to kafka transaction: %w", err))
+	}
+
+	if err := c.txnProducer.CommitTxn(); err != nil {
+		return c.abortTransaction(session, message, fmt.Errorf("failed to commit kafka transaction: %w", err))
+	}
+
+	metrics.KafkaMessagesProcessed.Inc()
+	return nil
+}
+
+// abortTransaction откатывает транзакцию и возвращает партицию к сообщению, чтобы оно было
+// прочитано заново в следующей сессии. При фатальной ошибке producer сессия завершается с ошибкой.
+func (c *Consumer) abortTransaction(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, cause error) error {
+	logger.Error("Kafka transaction failed", zap.Int64("offset", message.Offset), zap.Error(cause))
+
+	if c.txnProducer.TxnStatus()&sarama.ProducerTxnFlagFatalError != 0 {
+		return cause
+	}
+
+	if err := c.txnProducer.AbortTxn(); err != nil {
+		return fmt.Errorf("failed to abort kafka transaction: %w", err)
+	}
+
+	session.ResetOffset(message.Topic, message.Partition, message.Offset, "")
+	return cause
+}
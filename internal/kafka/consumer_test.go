@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestMessageMetaID(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *sarama.ConsumerMessage
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "header",
+			message: &sarama.ConsumerMessage{
+				Key:     []byte("SU100|2026-10-19T10:00:00Z"),
+				Headers: []*sarama.RecordHeader{{Key: []byte(MetaIDHeader), Value: []byte("42")}},
+			},
+			want: 42,
+		},
+		{name: "legacy key", message: &sarama.ConsumerMessage{Key: []byte("7")}, want: 7},
+		{name: "flight key without header", message: &sarama.ConsumerMessage{Key: []byte("SU100|2026-10-19T10:00:00Z")}, wantErr: true},
+		{name: "no key", message: &sarama.ConsumerMessage{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := messageMetaID(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("messageMetaID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("messageMetaID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"flight-service/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// OutboxRelay переносит события из таблицы outbox в Kafka. Relay запущен на каждой реплике,
+// но отправляет только владелец advisory lock, поэтому события уходят строго в порядке id
+// и в сжатом топике последним остается самое новое состояние рейса. Доставка at-least-once,
+// поэтому получатели должны быть идемпотентны; в сжатом топике это выполняется по ключу.
+type OutboxRelay struct {
+	producer sarama.SyncProducer
+	pool     *pgxpool.Pool
+	repo     repository.OutboxRepository
+	cfg      config.KafkaEventsConfig
+
+	closeChan chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewOutboxRelay(brokers []string, cfg config.KafkaEventsConfig, pool *pgxpool.Pool, repo repository.OutboxRepository) (*OutboxRelay, error) {
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V2_1_0_0
+	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+	saramaCfg.Producer.Idempotent = true
+	saramaCfg.Producer.Retry.Max = 3
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.Partitioner = sarama.NewHashPartitioner // события одного рейса попадают в одну партицию
+	saramaCfg.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer(brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать producer для outbox: %w", err)
+	}
+
+	return &OutboxRelay{
+		producer:  producer,
+		pool:      pool,
+		repo:      repo,
+		cfg:       cfg,
+		closeChan: make(chan struct{}),
+	}, nil
+}
+
+// Start запускает фоновую отправку событий
+func (r *OutboxRelay) Start() {
+	r.wg.Add(1)
+	go r.run()
+}
+
+func (r *OutboxRelay) run() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.RelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.closeChan:
+			return
+		case <-ticker.C:
+			// Отправляем пачки, пока outbox не опустеет
+			for {
+				sent, err := r.relayBatch()
+				if err != nil {
+					logger.Error("Failed to relay outbox messages", zap.Error(err))
+					break
+				}
+				if sent < r.cfg.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// relayBatch отправляет одну пачку и удаляет ее из outbox в той же транзакции
+func (r *OutboxRelay) relayBatch() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	repoWithTx := r.repo.WithTx(tx)
+
+	// Outbox уже отправляет другая реплика, попробуем на следующем тике
+	locked, err := repoWithTx.TryLockRelay(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	messages, err := repoWithTx.FetchPending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	batch := make([]*sarama.ProducerMessage, len(messages))
+	ids := make([]int64, len(messages))
+	for i, msg := range messages {
+		batch[i] = &sarama.ProducerMessage{
+			Topic: msg.Topic,
+			Key:   sarama.StringEncoder(msg.Key),
+			Value: sarama.ByteEncoder(msg.Payload),
+		}
+		ids[i] = msg.ID
+	}
+
+	if err := r.producer.SendMessages(batch); err != nil {
+		return 0, fmt.Errorf("failed to send outbox messages: %w", err)
+	}
+
+	if err := repoWithTx.Delete(ctx, ids); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	metrics.KafkaEventsPublished.Add(float64(len(messages)))
+	return len(messages), nil
+}
+
+// Close останавливает отправку и закрывает producer. Неотправленные события остаются в outbox.
+func (r *OutboxRelay) Close() error {
+	close(r.closeChan)
+	r.wg.Wait()
+	return r.producer.Close()
+}
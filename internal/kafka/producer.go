@@ -1,23 +1,51 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
 	"flight-service/internal/logger"
 	"flight-service/internal/metrics"
 	"flight-service/internal/model"
+	"flight-service/internal/repository"
 	"fmt"
 	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"strconv"
 	"sync"
 	"time"
 )
 
+// MetaIDHeader заголовок сообщения с id записи meta; ключом сообщения служит ключ рейса
+const MetaIDHeader = "meta_id"
+
+// Политики поведения при заполненной очереди
+const (
+	OverflowBlock  = "block"  // ждать освобождения места до enqueue_timeout или отмены запроса
+	OverflowReject = "reject" // сразу отказать, клиент получит 503
+	OverflowSpill  = "spill"  // сохранить сообщение в kafka_spill и отправить позже; пока таблица не пуста, туда попадают и новые сообщения
+)
+
 type Producer struct {
-	producer    sarama.SyncProducer
+	producer    sarama.AsyncProducer
 	topic       string
 	requestChan chan kafkaRequest
 	wg          sync.WaitGroup
+	deliveryWg  sync.WaitGroup // обработчики Successes/Errors, завершаются после AsyncClose
 	closeChan   chan struct{}
+
+	// mu защищает requestChan от закрытия во время отправки: отправители держат RLock,
+	// Close берет Lock только после того, как closeChan разбудил заблокированных отправителей
+	mu        sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+
+	cfg      config.KafkaProducerConfig
+	pool     *pgxpool.Pool
+	spill    repository.SpillRepository
+	metaRepo repository.MetaRepository
 }
 
 type kafkaRequest struct {
@@ -25,25 +53,40 @@ type kafkaRequest struct {
 	request *model.FlightRequest
 }
 
-// NewProducer создаёт новый экземпляр Producer с асинхронной отправкой
-func NewProducer(brokers []string, topic string) (*Producer, error) {
-	config := sarama.NewConfig()
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 3
-	config.Producer.Return.Successes = true
-	config.Producer.Return.Errors = true
-	config.Producer.Partitioner = sarama.NewRandomPartitioner
+// NewProducer создаёт новый экземпляр Producer поверх sarama.AsyncProducer.
+// pool и spill нужны только для политики OverflowSpill, metaRepo используется для пометки
+// meta как error, если Kafka не подтвердила доставку.
+func NewProducer(brokers []string, topic string, cfg config.KafkaProducerConfig, pool *pgxpool.Pool,
+	spill repository.SpillRepository, metaRepo repository.MetaRepository) (*Producer, error) {
+	if cfg.OverflowPolicy == OverflowSpill && (pool == nil || spill == nil) {
+		return nil, fmt.Errorf("overflow policy %q requires a database pool and a spill repository", OverflowSpill)
+	}
+
+	saramaCfg, err := newProducerConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	syncProducer, err := sarama.NewSyncProducer(brokers, config)
+	asyncProducer, err := sarama.NewAsyncProducer(brokers, saramaCfg)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось создать SyncProducer: %w", err)
+		return nil, fmt.Errorf("не удалось создать AsyncProducer: %w", err)
 	}
 
+	return newProducer(asyncProducer, topic, cfg, pool, spill, metaRepo), nil
+}
+
+// newProducer запускает фоновые горутины поверх готового AsyncProducer
+func newProducer(asyncProducer sarama.AsyncProducer, topic string, cfg config.KafkaProducerConfig,
+	pool *pgxpool.Pool, spill repository.SpillRepository, metaRepo repository.MetaRepository) *Producer {
 	p := &Producer{
-		producer:    syncProducer,
+		producer:    asyncProducer,
 		topic:       topic,
-		requestChan: make(chan kafkaRequest, 100), // Буферизированный канал
+		requestChan: make(chan kafkaRequest, cfg.QueueSize), // Буферизированный канал
 		closeChan:   make(chan struct{}),
+		cfg:         cfg,
+		pool:        pool,
+		spill:       spill,
+		metaRepo:    metaRepo,
 	}
 
 	// Запускаем горутину для асинхронной обработки
@@ -51,85 +94,299 @@ func NewProducer(brokers []string, topic string) (*Producer, error) {
 	go p.processRequests()
 	go p.metricsCollector()
 
-	return p, nil
+	p.deliveryWg.Add(2)
+	go p.handleSuccesses()
+	go p.handleErrors()
+
+	if cfg.OverflowPolicy == OverflowSpill {
+		p.wg.Add(1)
+		go p.drainSpill()
+	}
+
+	return p
+}
+
+var requiredAcks = map[string]sarama.RequiredAcks{
+	"NoResponse":   sarama.NoResponse,
+	"WaitForLocal": sarama.WaitForLocal,
+	"WaitForAll":   sarama.WaitForAll,
 }
 
-// SendFlightMessage отправляет сообщение FlightRequest в Kafka асинхронно
-func (p *Producer) SendFlightMessage(metaID int, request *model.FlightRequest) error {
-	// Проверяем, не закрыт ли producer
+// newProducerConfig настраивает батчинг, сжатие и идемпотентность
+func newProducerConfig(cfg config.KafkaProducerConfig) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_1_0_0 // минимальная версия для идемпотентности и zstd
+
+	acks, ok := requiredAcks[cfg.RequiredAcks]
+	if !ok {
+		return nil, fmt.Errorf("invalid kafka.producer.required_acks: %q", cfg.RequiredAcks)
+	}
+	config.Producer.RequiredAcks = acks
+
+	if err := config.Producer.Compression.UnmarshalText([]byte(cfg.Compression)); err != nil {
+		return nil, fmt.Errorf("invalid kafka.producer.compression: %w", err)
+	}
+
+	config.Producer.Retry.Max = cfg.RetryMax
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	// Сообщения одного рейса попадают в одну партицию и обрабатываются по порядку
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+
+	config.Producer.Flush.Frequency = cfg.FlushFrequency
+	config.Producer.Flush.Messages = cfg.FlushMessages
+	config.Producer.Flush.Bytes = cfg.FlushBytes
+
+	// Идемпотентный producer исключает дубли при ретраях, но требует acks=WaitForAll
+	// и не более одного запроса в полете на соединение
+	if cfg.Idempotent {
+		config.Producer.Idempotent = true
+		config.Net.MaxOpenRequests = 1
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid kafka producer config: %w", err)
+	}
+
+	return config, nil
+}
+
+// SendFlightMessage ставит сообщение FlightRequest в очередь на отправку в Kafka.
+// При заполненной очереди поведение определяется overflow_policy; ожидание ограничено
+// enqueue_timeout и контекстом запроса.
+func (p *Producer) SendFlightMessage(ctx context.Context, metaID int, request *model.FlightRequest) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		metrics.KafkaEnqueueResults.WithLabelValues("closed").Inc()
+		return domain.Unavailable("producer_closed", "kafka producer is closed", nil)
+	}
+
+	req := kafkaRequest{metaID: metaID, request: request}
+
+	// Пока отложенные сообщения не вернулись в очередь, новые встают за ними,
+	// иначе устаревшие данные рейса из kafka_spill перезаписали бы более новые
+	if p.cfg.OverflowPolicy == OverflowSpill {
+		pending, err := p.spill.HasPending(ctx)
+		if err != nil {
+			metrics.KafkaEnqueueResults.WithLabelValues("rejected").Inc()
+			return fmt.Errorf("failed to check spilled kafka messages: %w", err)
+		}
+		if pending {
+			return p.spillMessage(ctx, req, "Kafka spill is not drained yet, message spilled")
+		}
+	}
+
+	// Быстрый путь: в очереди есть место
 	select {
-	case <-p.closeChan:
-		return fmt.Errorf("producer is closed")
+	case p.requestChan <- req:
+		p.queued(req)
+		return nil
 	default:
 	}
 
-	// Отправляем в канал для асинхронной обработки
-	p.requestChan <- kafkaRequest{
-		metaID:  metaID,
-		request: request,
+	switch p.cfg.OverflowPolicy {
+	case OverflowReject:
+		metrics.KafkaEnqueueResults.WithLabelValues("rejected").Inc()
+		return domain.Unavailable("kafka_queue_full", "kafka queue is full, try again later", nil)
+	case OverflowSpill:
+		return p.spillMessage(ctx, req, "Kafka queue is full, message spilled")
 	}
 
+	timer := time.NewTimer(p.cfg.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.requestChan <- req:
+		p.queued(req)
+		return nil
+	case <-timer.C:
+		metrics.KafkaEnqueueResults.WithLabelValues("timeout").Inc()
+		return domain.Unavailable("kafka_queue_timeout", "timed out waiting for kafka queue", nil)
+	case <-ctx.Done():
+		metrics.KafkaEnqueueResults.WithLabelValues("timeout").Inc()
+		return domain.Unavailable("kafka_queue_timeout", "request cancelled while waiting for kafka queue", ctx.Err())
+	case <-p.closeChan:
+		metrics.KafkaEnqueueResults.WithLabelValues("closed").Inc()
+		return domain.Unavailable("producer_closed", "kafka producer is closed", nil)
+	}
+}
+
+func (p *Producer) queued(req kafkaRequest) {
+	metrics.KafkaEnqueueResults.WithLabelValues("queued").Inc()
 	logger.Info("Flight request queued for Kafka",
-		zap.Int("metaID", metaID),
-		zap.String("flightNumber", request.FlightNumber))
+		zap.Int("metaID", req.metaID),
+		zap.String("flightNumber", req.request.FlightNumber))
+}
 
+// spillMessage откладывает сообщение в kafka_spill
+func (p *Producer) spillMessage(ctx context.Context, req kafkaRequest, reason string) error {
+	if err := p.spill.Save(ctx, req.metaID, req.request); err != nil {
+		metrics.KafkaEnqueueResults.WithLabelValues("rejected").Inc()
+		return fmt.Errorf("failed to spill kafka message: %w", err)
+	}
+	metrics.KafkaEnqueueResults.WithLabelValues("spilled").Inc()
+	logger.Warn(reason,
+		zap.Int("metaID", req.metaID),
+		zap.String("flightNumber", req.request.FlightNumber))
 	return nil
 }
 
-// processRequests обрабатывает запросы из канала
-func (p *Producer) processRequests() {
+// drainSpill периодически возвращает отложенные сообщения в очередь, пока в ней есть место
+func (p *Producer) drainSpill() {
 	defer p.wg.Done()
 
+	ticker := time.NewTicker(p.cfg.SpillDrainInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-p.closeChan:
-			// Обрабатываем оставшиеся сообщения перед выходом
-			for req := range p.requestChan {
-				p.sendMessage(req.metaID, req.request)
-			}
 			return
-		case req, ok := <-p.requestChan:
-			if !ok {
-				return
-			}
-			p.sendMessage(req.metaID, req.request)
+		case <-ticker.C:
+			p.drainSpillOnce()
 		}
 	}
 }
 
-// sendMessage синхронно отправляет сообщение в Kafka
-func (p *Producer) sendMessage(metaID int, request *model.FlightRequest) {
-	jsonData, err := json.Marshal(request)
+// drainSpillOnce забирает из kafka_spill столько сообщений, сколько помещается в очередь.
+// Удаление фиксируется только после того, как все они поставлены в очередь; если это не удалось,
+// транзакция откатывается и пачка отправляется заново на следующем тике (at-least-once).
+func (p *Producer) drainSpillOnce() {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return
+	}
+
+	free := cap(p.requestChan) - len(p.requestChan)
+	if free <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.SpillDrainInterval)
+	defer cancel()
+
+	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		logger.Error("Failed to marshal FlightRequest to JSON",
-			zap.Int("metaID", metaID),
-			zap.Error(err))
+		logger.Error("Failed to begin spill drain transaction", zap.Error(err))
 		return
 	}
+	defer tx.Rollback(ctx)
 
-	msg := &sarama.ProducerMessage{
-		Topic: p.topic,
-		Key:   sarama.StringEncoder(fmt.Sprintf("%d", metaID)),
-		Value: sarama.StringEncoder(jsonData),
+	spillWithTx := p.spill.WithTx(tx)
+
+	// Таблицу уже разгружает другая реплика
+	locked, err := spillWithTx.TryLockDrain(ctx)
+	if err != nil {
+		logger.Error("Failed to lock kafka spill", zap.Error(err))
+		return
+	}
+	if !locked {
+		return
 	}
 
-	partition, offset, err := p.producer.SendMessage(msg)
+	messages, err := spillWithTx.Claim(ctx, free)
 	if err != nil {
-		logger.Error("Failed to send message to Kafka",
-			zap.Int("metaID", metaID),
-			zap.Error(err))
-		// Здесь можно добавить retry логику
-	} else {
+		logger.Error("Failed to claim spilled kafka messages", zap.Error(err))
+		return
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	// Пока таблица не пуста, новые сообщения тоже откладываются, поэтому место в очереди
+	// занимают только отложенные; ожидание ограничено тиком и остановкой producer
+	for _, msg := range messages {
+		select {
+		case p.requestChan <- kafkaRequest{metaID: msg.MetaID, request: msg.Request}:
+		case <-ctx.Done():
+			logger.Error("Timed out requeueing spilled kafka messages, the batch will be sent again", zap.Error(ctx.Err()))
+			return
+		case <-p.closeChan:
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Error("Failed to commit spill drain, the batch will be sent again", zap.Error(err))
+		return
+	}
+
+	logger.Info("Spilled kafka messages requeued", zap.Int("count", len(messages)))
+}
+
+// processRequests передает сообщения из очереди в AsyncProducer, батчинг выполняет sarama
+func (p *Producer) processRequests() {
+	defer p.wg.Done()
+
+	// Канал закрывается в Close, оставшиеся сообщения передаются в producer перед выходом
+	for req := range p.requestChan {
+		jsonData, err := json.Marshal(req.request)
+		if err != nil {
+			logger.Error("Failed to marshal FlightRequest to JSON",
+				zap.Int("metaID", req.metaID),
+				zap.Error(err))
+			p.markFailed(req.metaID)
+			continue
+		}
+
+		p.producer.Input() <- &sarama.ProducerMessage{
+			Topic:    p.topic,
+			Key:      sarama.StringEncoder(model.FlightKey(req.request.FlightNumber, req.request.DepartureDate)),
+			Value:    sarama.ByteEncoder(jsonData),
+			Headers:  []sarama.RecordHeader{{Key: []byte(MetaIDHeader), Value: []byte(strconv.Itoa(req.metaID))}},
+			Metadata: req,
+		}
+	}
+}
+
+// handleSuccesses обрабатывает подтверждения доставки
+func (p *Producer) handleSuccesses() {
+	defer p.deliveryWg.Done()
+
+	for msg := range p.producer.Successes() {
+		req := msg.Metadata.(kafkaRequest)
 		metrics.KafkaMessagesSent.Inc()
 		logger.Info("Message sent to Kafka",
-			zap.Int("metaID", metaID),
-			zap.String("flightNumber", request.FlightNumber),
-			zap.Int32("partition", partition),
-			zap.Int64("offset", offset))
+			zap.Int("metaID", req.metaID),
+			zap.String("flightNumber", req.request.FlightNumber),
+			zap.Int32("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset))
+	}
+}
+
+// handleErrors помечает meta как error для сообщений, которые не удалось доставить после всех ретраев
+func (p *Producer) handleErrors() {
+	defer p.deliveryWg.Done()
+
+	for producerErr := range p.producer.Errors() {
+		req := producerErr.Msg.Metadata.(kafkaRequest)
+		logger.Error("Failed to send message to Kafka",
+			zap.Int("metaID", req.metaID),
+			zap.Error(producerErr.Err))
+		p.markFailed(req.metaID)
+	}
+}
+
+func (p *Producer) markFailed(metaID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := p.metaRepo.UpdateStatus(ctx, metaID, "error"); err != nil {
+		logger.Error("Failed to mark flight meta as error", zap.Int("metaID", metaID), zap.Error(err))
+		return
 	}
+
+	metrics.FlightMetaStatusCount.WithLabelValues("pending").Dec()
+	metrics.FlightMetaStatusCount.WithLabelValues("error").Inc()
 }
 
 func (p *Producer) metricsCollector() {
+	defer p.wg.Done()
+
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
@@ -144,12 +401,23 @@ func (p *Producer) metricsCollector() {
 	}
 }
 
-// Close закрывает соединение с Kafka
+// Close закрывает соединение с Kafka. Безопасен при одновременных вызовах SendFlightMessage:
+// ожидающие отправители получают отказ, уже поставленные в очередь сообщения отправляются.
 func (p *Producer) Close() error {
-	close(p.closeChan)   // Сигнализируем горутине о завершении
-	close(p.requestChan) // Закрываем канал
-	p.wg.Wait()          // Ждем завершения горутины
+	p.closeOnce.Do(func() {
+		close(p.closeChan) // Будим заблокированных отправителей и фоновые горутины
 
-	// Закрываем соединение с Kafka
-	return p.producer.Close()
+		p.mu.Lock()
+		p.closed = true
+		close(p.requestChan) // Отправителей с RLock больше нет, канал можно закрыть
+		p.mu.Unlock()
+
+		p.wg.Wait() // Ждем, пока все сообщения из очереди попадут в producer
+
+		// AsyncClose дожидается отправки буферизованных сообщений и закрывает Successes/Errors,
+		// после чего обработчики доставки завершаются
+		p.producer.AsyncClose()
+		p.deliveryWg.Wait()
+	})
+	return nil
 }
@@ -0,0 +1,128 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestProducerCloseAfterSend(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	saramaCfg := mocks.NewTestConfig()
+	saramaCfg.Producer.Return.Successes = true
+	asyncProducer := mocks.NewAsyncProducer(t, saramaCfg)
+	asyncProducer.ExpectInputWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		key, err := msg.Key.Encode()
+		if err != nil {
+			return err
+		}
+		if string(key) != "SU100|2026-10-19T10:00:00Z" {
+			return fmt.Errorf("key = %q, want flight key", key)
+		}
+		if len(msg.Headers) != 1 || string(msg.Headers[0].Key) != MetaIDHeader || string(msg.Headers[0].Value) != "1" {
+			return fmt.Errorf("headers = %v, want meta_id=1", msg.Headers)
+		}
+		return nil
+	})
+
+	p := newProducer(asyncProducer, "flights", config.KafkaProducerConfig{
+		QueueSize:      1,
+		EnqueueTimeout: time.Second,
+		OverflowPolicy: OverflowBlock,
+	}, nil, nil, nil)
+
+	request := &model.FlightRequest{
+		FlightNumber:  "SU100",
+		DepartureDate: time.Date(2026, 10, 19, 13, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+	}
+	if err := p.SendFlightMessage(context.Background(), 1, request); err != nil {
+		t.Fatalf("SendFlightMessage() error = %v", err)
+	}
+
+	closed := make(chan error, 1)
+	go func() { closed <- p.Close() }()
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Close() did not return")
+	}
+
+	if err := p.SendFlightMessage(context.Background(), 2, request); err == nil {
+		t.Fatal("SendFlightMessage() after Close() succeeded")
+	}
+}
+
+// fakeSpillRepository хранит отложенные сообщения в памяти
+type fakeSpillRepository struct {
+	saved []int
+}
+
+func (r *fakeSpillRepository) WithTx(pgx.Tx) repository.SpillRepository { return r }
+
+func (r *fakeSpillRepository) Save(_ context.Context, metaID int, _ *model.FlightRequest) error {
+	r.saved = append(r.saved, metaID)
+	return nil
+}
+
+func (r *fakeSpillRepository) HasPending(context.Context) (bool, error) { return len(r.saved) > 0, nil }
+
+func (r *fakeSpillRepository) TryLockDrain(context.Context) (bool, error) { return true, nil }
+
+func (r *fakeSpillRepository) Claim(context.Context, int) ([]*model.SpilledMessage, error) {
+	return nil, nil
+}
+
+func TestProducerSpillsBehindPendingMessages(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	saramaCfg := mocks.NewTestConfig()
+	saramaCfg.Producer.Return.Successes = true
+	asyncProducer := mocks.NewAsyncProducer(t, saramaCfg)
+	asyncProducer.ExpectInputAndSucceed()
+
+	spill := &fakeSpillRepository{}
+	p := newProducer(asyncProducer, "flights", config.KafkaProducerConfig{
+		QueueSize:          1,
+		OverflowPolicy:     OverflowSpill,
+		SpillDrainInterval: time.Hour, // разгрузка в тесте не запускается
+	}, nil, spill, nil)
+
+	request := &model.FlightRequest{FlightNumber: "SU100", DepartureDate: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)}
+
+	// Пустая таблица: сообщение идет в очередь
+	if err := p.SendFlightMessage(context.Background(), 1, request); err != nil {
+		t.Fatalf("SendFlightMessage(1) error = %v", err)
+	}
+	if len(spill.saved) != 0 {
+		t.Fatalf("spilled = %v, want none", spill.saved)
+	}
+
+	// Есть отложенные сообщения: новые встают за ними, даже если в очереди есть место
+	spill.saved = []int{2}
+	if err := p.SendFlightMessage(context.Background(), 3, request); err != nil {
+		t.Fatalf("SendFlightMessage(3) error = %v", err)
+	}
+	if len(spill.saved) != 2 || spill.saved[1] != 3 {
+		t.Errorf("spilled = %v, want [2 3]", spill.saved)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
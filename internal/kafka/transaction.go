@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"flight-service/internal/model"
+
+	"github.com/IBM/sarama"
+)
+
+type txnContextKey struct{}
+
+// txnMessage сообщение, обрабатываемое внутри Kafka транзакции consumer
+type txnMessage struct {
+	offset   model.KafkaOffset
+	producer sarama.AsyncProducer
+}
+
+func withTransaction(ctx context.Context, groupID string, message *sarama.ConsumerMessage, producer sarama.AsyncProducer) context.Context {
+	return context.WithValue(ctx, txnContextKey{}, &txnMessage{
+		offset: model.KafkaOffset{
+			GroupID:   groupID,
+			Topic:     message.Topic,
+			Partition: message.Partition,
+			Offset:    message.Offset,
+		},
+		producer: producer,
+	})
+}
+
+// TransactionOffset возвращает координаты сообщения, если обработка идет в транзакционном режиме
+func TransactionOffset(ctx context.Context) (model.KafkaOffset, bool) {
+	txn, ok := ctx.Value(txnContextKey{}).(*txnMessage)
+	if !ok {
+		return model.KafkaOffset{}, false
+	}
+	return txn.offset, true
+}
+
+// EmitInTransaction отправляет сообщение в текущей Kafka транзакции consumer: оно станет видно
+// читателям с read_committed только вместе с коммитом offset. Возвращает false вне транзакции.
+func EmitInTransaction(ctx context.Context, msg *model.OutboxMessage) bool {
+	txn, ok := ctx.Value(txnContextKey{}).(*txnMessage)
+	if !ok {
+		return false
+	}
+	txn.producer.Input() <- &sarama.ProducerMessage{
+		Topic: msg.Topic,
+		Key:   sarama.StringEncoder(msg.Key),
+		Value: sarama.ByteEncoder(msg.Payload),
+	}
+	return true
+}
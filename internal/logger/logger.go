@@ -16,10 +16,18 @@ func Init(core zapcore.Core, options ...zap.Option) {
 //	return globalLogger
 //}
 
+func Debug(msg string, fields ...zap.Field) {
+	globalLogger.Debug(msg, fields...)
+}
+
 func Info(msg string, fields ...zap.Field) {
 	globalLogger.Info(msg, fields...)
 }
 
+func Warn(msg string, fields ...zap.Field) {
+	globalLogger.Warn(msg, fields...)
+}
+
 func Error(msg string, fields ...zap.Field) {
 	globalLogger.Error(msg, fields...)
 }
@@ -27,3 +35,8 @@ func Error(msg string, fields ...zap.Field) {
 func Fatal(msg string, fields ...zap.Field) {
 	globalLogger.Fatal(msg, fields...)
 }
+
+// Sync сбрасывает буферизированные записи логов
+func Sync() error {
+	return globalLogger.Sync()
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DBPoolCollector отдает статистику пула соединений Postgres в момент сбора метрик
+type DBPoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	emptyAcquire     *prometheus.Desc
+	acquireWait      *prometheus.Desc
+	canceledAcquires *prometheus.Desc
+}
+
+func NewDBPoolCollector(pool *pgxpool.Pool) *DBPoolCollector {
+	return &DBPoolCollector{
+		pool: pool,
+		acquiredConns: prometheus.NewDesc("db_pool_acquired_connections",
+			"Number of currently acquired connections in the pool", nil, nil),
+		idleConns: prometheus.NewDesc("db_pool_idle_connections",
+			"Number of currently idle connections in the pool", nil, nil),
+		totalConns: prometheus.NewDesc("db_pool_total_connections",
+			"Total number of connections currently in the pool", nil, nil),
+		maxConns: prometheus.NewDesc("db_pool_max_connections",
+			"Maximum size of the pool", nil, nil),
+		acquireCount: prometheus.NewDesc("db_pool_acquire_total",
+			"Total number of successful connection acquires from the pool", nil, nil),
+		emptyAcquire: prometheus.NewDesc("db_pool_empty_acquire_total",
+			"Total number of acquires that had to wait for a connection", nil, nil),
+		acquireWait: prometheus.NewDesc("db_pool_acquire_wait_seconds_total",
+			"Total time spent waiting for a connection when the pool was empty", nil, nil),
+		canceledAcquires: prometheus.NewDesc("db_pool_canceled_acquire_total",
+			"Total number of acquires cancelled by context", nil, nil),
+	}
+}
+
+func (c *DBPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquire
+	ch <- c.acquireWait
+	ch <- c.canceledAcquires
+}
+
+func (c *DBPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
@@ -22,6 +22,46 @@ var (
 		[]string{"method", "endpoint"},
 	)
 
+	GrpcRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of gRPC requests",
+		},
+		[]string{"method", "code"},
+	)
+
+	GrpcDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "Duration of gRPC requests in seconds",
+			Buckets: []float64{0.1, 0.3, 0.5, 1.0, 2.0, 5.0},
+		},
+		[]string{"method"},
+	)
+
+	AuthFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_failures_total",
+			Help: "Total number of rejected authentication and authorization attempts",
+		},
+		[]string{"reason"},
+	)
+
+	RateLimitRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_rejections_total",
+			Help: "Total number of requests rejected by the rate limiter",
+		},
+		[]string{"route"},
+	)
+
+	RateLimitErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rate_limit_errors_total",
+			Help: "Total number of rate limiter backend failures",
+		},
+	)
+
 	DbQueryDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "db_query_duration_seconds",
@@ -31,6 +71,23 @@ var (
 		[]string{"table", "operation"},
 	)
 
+	DbQueryRows = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_rows",
+			Help:    "Number of rows returned or affected by database queries",
+			Buckets: []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+		},
+		[]string{"table", "operation"},
+	)
+
+	DbQueryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Total number of failed database queries",
+		},
+		[]string{"table", "operation"},
+	)
+
 	KafkaMessagesSent = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "kafka_messages_sent_total",
@@ -38,6 +95,14 @@ var (
 		},
 	)
 
+	KafkaEnqueueResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kafka_enqueue_total",
+			Help: "Total number of producer enqueue attempts by result",
+		},
+		[]string{"result"}, // queued, spilled, rejected, timeout, closed
+	)
+
 	KafkaMessagesProcessed = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "kafka_messages_processed_total",
@@ -45,6 +110,56 @@ var (
 		},
 	)
 
+	WebhookDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by result",
+		},
+		[]string{"result"}, // delivered, retry, failed
+	)
+
+	WebhooksDisabled = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "webhooks_disabled_total",
+			Help: "Total number of webhook subscriptions disabled after repeated failures",
+		},
+	)
+
+	StreamSubscribers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "flight_stream_subscribers",
+			Help: "Current number of SSE clients subscribed to flight updates",
+		},
+	)
+
+	StreamEventsDispatched = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "flight_stream_events_dispatched_total",
+			Help: "Total number of flight stream events received via NOTIFY and dispatched to subscribers",
+		},
+	)
+
+	StreamSlowSubscribers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "flight_stream_slow_subscribers_total",
+			Help: "Total number of SSE clients disconnected because their event buffer overflowed",
+		},
+	)
+
+	KafkaEventsPublished = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kafka_events_published_total",
+			Help: "Total number of outbox events published to Kafka",
+		},
+	)
+
+	KafkaDuplicateMessages = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kafka_duplicate_messages_total",
+			Help: "Total number of redelivered Kafka messages skipped by the consumer inbox",
+		},
+	)
+
 	KafkaProcessingErrors = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "kafka_processing_errors_total",
@@ -66,6 +181,23 @@ var (
 		},
 	)
 
+	FlightStatusTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flight_status_transitions_total",
+			Help: "Total number of flight operational status transitions",
+		},
+		[]string{"from", "to"},
+	)
+
+	DepartureDelay = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "flight_departure_delay_minutes",
+			Help:    "Departure delay against schedule in minutes, observed when the actual departure time becomes known",
+			Buckets: []float64{0, 5, 15, 30, 45, 60, 90, 120, 180, 240, 360},
+		},
+		[]string{"aircraft_type"},
+	)
+
 	Passengers = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "passengers_per_flight",
@@ -102,12 +234,29 @@ var (
 func Register() {
 	prometheus.MustRegister(HttpRequests)
 	prometheus.MustRegister(HttpDuration)
+	prometheus.MustRegister(GrpcRequests)
+	prometheus.MustRegister(GrpcDuration)
+	prometheus.MustRegister(AuthFailures)
+	prometheus.MustRegister(RateLimitRejections)
+	prometheus.MustRegister(RateLimitErrors)
 	prometheus.MustRegister(DbQueryDuration)
+	prometheus.MustRegister(DbQueryRows)
+	prometheus.MustRegister(DbQueryErrors)
 	prometheus.MustRegister(KafkaMessagesSent)
+	prometheus.MustRegister(KafkaEnqueueResults)
 	prometheus.MustRegister(KafkaMessagesProcessed)
+	prometheus.MustRegister(KafkaEventsPublished)
+	prometheus.MustRegister(WebhookDeliveries)
+	prometheus.MustRegister(WebhooksDisabled)
+	prometheus.MustRegister(StreamSubscribers)
+	prometheus.MustRegister(StreamEventsDispatched)
+	prometheus.MustRegister(StreamSlowSubscribers)
+	prometheus.MustRegister(KafkaDuplicateMessages)
 	prometheus.MustRegister(KafkaProcessingErrors)
 	prometheus.MustRegister(KafkaConsumerLag)
 	prometheus.MustRegister(FlightsProcessed)
+	prometheus.MustRegister(FlightStatusTransitions)
+	prometheus.MustRegister(DepartureDelay)
 	prometheus.MustRegister(Passengers)
 	prometheus.MustRegister(AircraftTypeCount)
 	prometheus.MustRegister(ChannelSize)
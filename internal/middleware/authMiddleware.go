@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"flight-service/internal/auth"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// AuthMiddleware аутентифицирует клиента по X-API-Key или Authorization: Bearer
+// и проверяет, что у него есть область доступа scope
+func AuthMiddleware(authenticator *auth.Authenticator, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authenticator.Enabled() {
+			c.Next()
+			return
+		}
+
+		bearer, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		principal, err := authenticator.Authenticate(c.Request.Context(), bearer, c.GetHeader(apiKeyHeader))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if err := authenticator.Authorize(principal, scope); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem тело ответа об ошибке по RFC 7807
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+// ErrorMiddleware превращает ошибки, добавленные обработчиками через c.Error, в ответ problem+json
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		problem := newProblem(err, c.Request.URL.Path)
+
+		if problem.Status >= http.StatusInternalServerError {
+			logger.Error("Request failed",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.FullPath()),
+				zap.Error(err))
+		}
+
+		if problem.Status == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", `Bearer realm="flight-service"`)
+		}
+
+		// gin не перезаписывает уже выставленный Content-Type
+		c.Header("Content-Type", problemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+func newProblem(err error, instance string) Problem {
+	status, code := http.StatusInternalServerError, "internal_error"
+	detail := "internal server error"
+
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		status, code = http.StatusNotFound, "not_found"
+	case errors.Is(err, domain.ErrGone):
+		status, code = http.StatusGone, "gone"
+	case errors.Is(err, domain.ErrConflict):
+		status, code = http.StatusConflict, "conflict"
+	case errors.Is(err, domain.ErrValidation):
+		status, code = http.StatusBadRequest, "validation_failed"
+	case errors.Is(err, domain.ErrUnavailable):
+		status, code = http.StatusServiceUnavailable, "unavailable"
+	case errors.Is(err, domain.ErrUnauthenticated):
+		status, code = http.StatusUnauthorized, "unauthenticated"
+	case errors.Is(err, domain.ErrForbidden):
+		status, code = http.StatusForbidden, "forbidden"
+	case errors.Is(err, domain.ErrRateLimited):
+		status, code = http.StatusTooManyRequests, "rate_limited"
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		code = domainErr.Code
+		// Причину не раскрываем клиенту, отдаем только сообщение
+		detail = domainErr.Message
+	}
+
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
+	"flight-service/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware ограничивает частоту запросов клиента к маршруту.
+// Клиент определяется по Principal, для анонимных запросов по IP.
+func RateLimitMiddleware(limiter ratelimit.Limiter, cfg *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID := auth.CallerID(c.Request.Context())
+		if clientID == "" {
+			clientID = c.ClientIP()
+		}
+
+		retryAfter, err := ratelimit.Check(c.Request.Context(), limiter, cfg.Current().RateLimit,
+			clientID, c.Request.Method+" "+c.FullPath())
+		if err != nil {
+			c.Header("Retry-After", ratelimit.RetryAfterSeconds(retryAfter))
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// IPRateLimitMiddleware ограничивает частоту запросов с одного IP до аутентификации
+func IPRateLimitMiddleware(limiter ratelimit.Limiter, cfg *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		retryAfter, err := ratelimit.CheckIP(c.Request.Context(), limiter, cfg.Current().RateLimit, c.ClientIP())
+		if err != nil {
+			c.Header("Retry-After", ratelimit.RetryAfterSeconds(retryAfter))
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
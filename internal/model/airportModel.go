@@ -0,0 +1,12 @@
+package model
+
+// Airport запись справочника аэропортов
+type Airport struct {
+	Code      string  `db:"code"` // IATA
+	Name      string  `db:"name"`
+	City      string  `db:"city"`
+	Country   string  `db:"country"`  // ISO 3166-1 alpha-2
+	Timezone  string  `db:"timezone"` // IANA, например Europe/Moscow
+	Latitude  float64 `db:"latitude"`
+	Longitude float64 `db:"longitude"`
+}
@@ -0,0 +1,256 @@
+package model
+
+import "time"
+
+// CreateFlightResponse ответ на POST /api/flights
+type CreateFlightResponse struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+}
+
+// FlightResponse ответ на GET /api/flights
+type FlightResponse struct {
+	AircraftType    string `json:"aircraft_type"`
+	FlightNumber    string `json:"flight_number"`
+	DepartureDate   string `json:"departure_date"`
+	ArrivalDate     string `json:"arrival_date"`
+	PassengersCount int    `json:"passengers_count"`
+	UpdatedAt       string `json:"updated_at"`
+	Cancelled       bool   `json:"cancelled"`
+	CancelledAt     string `json:"cancelled_at,omitempty"`
+	DeletedAt       string `json:"deleted_at,omitempty"` // только в событиях flight.deleted
+	Status          string `json:"status"`
+	StatusChangedAt string `json:"status_changed_at,omitempty"`
+
+	EstimatedDeparture    string   `json:"estimated_departure,omitempty"`
+	ActualDeparture       string   `json:"actual_departure,omitempty"`
+	EstimatedArrival      string   `json:"estimated_arrival,omitempty"`
+	ActualArrival         string   `json:"actual_arrival,omitempty"`
+	DepartureDelayMinutes *int     `json:"departure_delay_minutes,omitempty"`
+	ArrivalDelayMinutes   *int     `json:"arrival_delay_minutes,omitempty"`
+	DelayCodes            []string `json:"delay_codes,omitempty"`
+
+	Origin      string `json:"origin,omitempty"`
+	Destination string `json:"destination,omitempty"`
+}
+
+// FlightDayItem вылет рейса с местным временем аэропорта вылета
+type FlightDayItem struct {
+	FlightResponse
+	DepartureLocal string `json:"departure_local"`
+	Timezone       string `json:"timezone"`
+}
+
+// FlightDayListResponse ответ на GET /api/flights/:flight_number/:date
+type FlightDayListResponse struct {
+	FlightNumber string          `json:"flight_number"`
+	Date         string          `json:"date"`
+	Flights      []FlightDayItem `json:"flights"`
+}
+
+// FlightMetaItem запись истории обработки рейса
+type FlightMetaItem struct {
+	ID            int    `json:"id"`
+	FlightNumber  string `json:"flight_number"`
+	DepartureDate string `json:"departure_date"`
+	Status        string `json:"status"`
+	CreatedAt     string `json:"created_at"`
+	ProcessedAt   string `json:"processed_at"`
+	CreatedBy     string `json:"created_by"`
+	Error         string `json:"error,omitempty"`
+}
+
+// FlightMetaListResponse ответ на GET /api/flights/:flight_number/meta
+type FlightMetaListResponse struct {
+	FlightNumber string           `json:"flight_number"`
+	Meta         []FlightMetaItem `json:"meta"`
+	Pagination   Pagination       `json:"pagination"`
+}
+
+// FlightHistoryChange изменение одного поля рейса; old равно null, если рейс создан этой записью
+type FlightHistoryChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// FlightHistoryItem запись истории изменений рейса
+type FlightHistoryItem struct {
+	ID        int64                 `json:"id"`
+	MetaID    int                   `json:"meta_id"`
+	Source    string                `json:"source"`
+	CreatedBy string                `json:"created_by"`
+	ChangedAt string                `json:"changed_at"`
+	ValidFrom string                `json:"valid_from"`
+	ValidTo   string                `json:"valid_to"` // пусто у текущей версии
+	Changes   []FlightHistoryChange `json:"changes"`
+}
+
+// FlightHistoryListResponse ответ на GET /api/flights/history
+type FlightHistoryListResponse struct {
+	FlightNumber  string              `json:"flight_number"`
+	DepartureDate string              `json:"departure_date"`
+	History       []FlightHistoryItem `json:"history"`
+	Pagination    Pagination          `json:"pagination"`
+}
+
+// FlightStatusTransitionItem переход рейса между операционными статусами
+type FlightStatusTransitionItem struct {
+	ID         int64  `json:"id"`
+	MetaID     int    `json:"meta_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	OccurredAt string `json:"occurred_at"`
+	RecordedAt string `json:"recorded_at"`
+}
+
+// FlightStatusListResponse ответ на GET /api/flights/status
+type FlightStatusListResponse struct {
+	FlightNumber    string                       `json:"flight_number"`
+	DepartureDate   string                       `json:"departure_date"`
+	Status          string                       `json:"status"`
+	StatusChangedAt string                       `json:"status_changed_at,omitempty"`
+	Transitions     []FlightStatusTransitionItem `json:"transitions"`
+}
+
+// LogLevel тело запроса и ответа /admin/loglevel
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+func NewFlightResponse(flight *FlightData) FlightResponse {
+	return FlightResponse{
+		AircraftType:    flight.AircraftType,
+		FlightNumber:    flight.FlightNumber,
+		DepartureDate:   flight.DepartureDate.Format(time.RFC3339),
+		ArrivalDate:     flight.ArrivalDate.Format(time.RFC3339),
+		PassengersCount: flight.PassengersCount,
+		UpdatedAt:       flight.UpdatedAt.Format(time.RFC3339),
+		Cancelled:       flight.CancelledAt != nil,
+		CancelledAt:     formatOptionalTime(flight.CancelledAt),
+		DeletedAt:       formatOptionalTime(flight.DeletedAt),
+		Status:          flight.Status,
+		StatusChangedAt: formatOptionalTime(flight.StatusChangedAt),
+
+		EstimatedDeparture:    formatOptionalTime(flight.EstimatedDeparture),
+		ActualDeparture:       formatOptionalTime(flight.ActualDeparture),
+		EstimatedArrival:      formatOptionalTime(flight.EstimatedArrival),
+		ActualArrival:         formatOptionalTime(flight.ActualArrival),
+		DepartureDelayMinutes: flight.DepartureDelayMinutes(),
+		ArrivalDelayMinutes:   flight.ArrivalDelayMinutes(),
+		DelayCodes:            flight.DelayCodes,
+
+		Origin:      flight.Origin,
+		Destination: flight.Destination,
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func NewFlightDayListResponse(response *FlightDayResponse) FlightDayListResponse {
+	flights := make([]FlightDayItem, len(response.Flights))
+	for i, flight := range response.Flights {
+		flights[i] = FlightDayItem{
+			FlightResponse: NewFlightResponse(flight.FlightData),
+			DepartureLocal: flight.DepartureLocal.Format(time.RFC3339),
+			Timezone:       flight.DepartureLocal.Location().String(),
+		}
+	}
+
+	return FlightDayListResponse{
+		FlightNumber: response.FlightNumber,
+		Date:         response.Date.Format(time.DateOnly),
+		Flights:      flights,
+	}
+}
+
+func NewFlightMetaListResponse(response *FlightMetaResponse) FlightMetaListResponse {
+	metaList := make([]FlightMetaItem, len(response.Meta))
+	for i, meta := range response.Meta {
+		processedAt := ""
+		if meta.ProcessedAt != nil && !meta.ProcessedAt.IsZero() {
+			processedAt = meta.ProcessedAt.Format(time.RFC3339)
+		}
+
+		metaList[i] = FlightMetaItem{
+			ID:            meta.ID,
+			FlightNumber:  meta.FlightNumber,
+			DepartureDate: meta.DepartureDate.Format(time.RFC3339),
+			Status:        meta.Status,
+			CreatedAt:     meta.CreatedAt.Format(time.RFC3339),
+			ProcessedAt:   processedAt,
+			CreatedBy:     meta.CreatedBy,
+			Error:         meta.Error,
+		}
+	}
+
+	return FlightMetaListResponse{
+		FlightNumber: response.FlightNumber,
+		Meta:         metaList,
+		Pagination:   response.Pagination,
+	}
+}
+
+func NewFlightHistoryListResponse(response *FlightHistoryResponse) FlightHistoryListResponse {
+	history := make([]FlightHistoryItem, len(response.History))
+	for i, entry := range response.History {
+		changes := make([]FlightHistoryChange, len(entry.ChangedFields))
+		for j, field := range entry.ChangedFields {
+			changes[j] = FlightHistoryChange{
+				Field: field,
+				Old:   entry.OldValues.Field(field),
+				New:   entry.NewValues.Field(field),
+			}
+		}
+
+		validTo := ""
+		if entry.ValidTo != nil {
+			validTo = entry.ValidTo.Format(time.RFC3339Nano)
+		}
+
+		history[i] = FlightHistoryItem{
+			ID:        entry.ID,
+			MetaID:    entry.MetaID,
+			Source:    entry.Source,
+			CreatedBy: entry.CreatedBy,
+			ChangedAt: entry.ChangedAt.Format(time.RFC3339),
+			ValidFrom: entry.ValidFrom.Format(time.RFC3339Nano),
+			ValidTo:   validTo,
+			Changes:   changes,
+		}
+	}
+
+	return FlightHistoryListResponse{
+		FlightNumber:  response.FlightNumber,
+		DepartureDate: response.DepartureDate.Format(time.RFC3339),
+		History:       history,
+		Pagination:    response.Pagination,
+	}
+}
+
+func NewFlightStatusListResponse(response *FlightStatusResponse) FlightStatusListResponse {
+	transitions := make([]FlightStatusTransitionItem, len(response.Transitions))
+	for i, transition := range response.Transitions {
+		transitions[i] = FlightStatusTransitionItem{
+			ID:         transition.ID,
+			MetaID:     transition.MetaID,
+			From:       transition.FromStatus,
+			To:         transition.ToStatus,
+			OccurredAt: transition.OccurredAt.Format(time.RFC3339),
+			RecordedAt: transition.RecordedAt.Format(time.RFC3339),
+		}
+	}
+
+	return FlightStatusListResponse{
+		FlightNumber:    response.Flight.FlightNumber,
+		DepartureDate:   response.Flight.DepartureDate.Format(time.RFC3339),
+		Status:          response.Flight.Status,
+		StatusChangedAt: formatOptionalTime(response.Flight.StatusChangedAt),
+		Transitions:     transitions,
+	}
+}
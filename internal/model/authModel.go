@@ -0,0 +1,8 @@
+package model
+
+// APIKey ключ клиента из таблицы api_keys
+type APIKey struct {
+	ClientID string   `db:"client_id"`
+	KeyHash  string   `db:"key_hash"`
+	Scopes   []string `db:"scopes"`
+}
@@ -2,12 +2,95 @@ package model
 
 import "time"
 
+// Операции над рейсом во входном топике Kafka
+const (
+	FlightOpUpsert  = "upsert" // по умолчанию, в том числе для сообщений без поля operation
+	FlightOpCancel  = "cancel"
+	FlightOpDelete  = "delete" // мягкое удаление, рейс можно восстановить
+	FlightOpRestore = "restore"
+	FlightOpPatch   = "patch"  // частичное обновление, меняются только поля из UpdateMask
+	FlightOpStatus  = "status" // смена операционного статуса, см. FlightStatuses
+)
+
+// Поля рейса, которые можно передать в маске частичного обновления
+const (
+	FlightFieldAircraftType    = "aircraft_type"
+	FlightFieldArrivalDate     = "arrival_date"
+	FlightFieldPassengersCount = "passengers_count"
+
+	FlightFieldEstimatedDeparture = "estimated_departure"
+	FlightFieldActualDeparture    = "actual_departure"
+	FlightFieldEstimatedArrival   = "estimated_arrival"
+	FlightFieldActualArrival      = "actual_arrival"
+	FlightFieldDelayCodes         = "delay_codes"
+
+	FlightFieldOrigin      = "origin"
+	FlightFieldDestination = "destination"
+)
+
+// FlightPatchFields все поля, доступные для частичного обновления
+var FlightPatchFields = []string{FlightFieldAircraftType, FlightFieldArrivalDate, FlightFieldPassengersCount,
+	FlightFieldEstimatedDeparture, FlightFieldActualDeparture, FlightFieldEstimatedArrival, FlightFieldActualArrival,
+	FlightFieldDelayCodes, FlightFieldOrigin, FlightFieldDestination}
+
+// FlightKey ключ рейса в Kafka: сообщения одного рейса попадают в одну партицию,
+// по нему же сжимается топик событий
+func FlightKey(flightNumber string, departureDate time.Time) string {
+	return flightNumber + "|" + departureDate.UTC().Format(time.RFC3339)
+}
+
 type FlightRequest struct {
 	AircraftType    string    `json:"aircraft_type"`
 	FlightNumber    string    `json:"flight_number"`
 	DepartureDate   time.Time `json:"departure_date"`
 	ArrivalDate     time.Time `json:"arrival_date"`
 	PassengersCount int       `json:"passengers_count"`
+
+	// DepartureDate и ArrivalDate время по расписанию, расчетное и фактическое передаются отдельно
+	EstimatedDeparture *time.Time `json:"estimated_departure,omitempty"`
+	ActualDeparture    *time.Time `json:"actual_departure,omitempty"`
+	EstimatedArrival   *time.Time `json:"estimated_arrival,omitempty"`
+	ActualArrival      *time.Time `json:"actual_arrival,omitempty"`
+	// DelayCodes коды причин задержки IATA AHM 730, основная причина первой
+	DelayCodes []string `json:"delay_codes,omitempty"`
+
+	// Origin и Destination IATA коды аэропортов из справочника airports
+	Origin      string `json:"origin,omitempty"`
+	Destination string `json:"destination,omitempty"`
+
+	// Operation задается сервисом при постановке в очередь, из тела POST /api/flights не принимается
+	Operation string `json:"operation,omitempty"`
+	// UpdateMask поля, которые меняет операция patch; остальные поля рейса не затрагиваются
+	UpdateMask []string `json:"update_mask,omitempty"`
+	// Status и StatusAt новый статус и время события для операции status
+	Status   string     `json:"status,omitempty"`
+	StatusAt *time.Time `json:"status_at,omitempty"`
+}
+
+// FlightPatchRequest тело PATCH /api/flights. Без update_mask обновляются переданные поля,
+// с маской - ровно поля из маски, отсутствующие в теле сбрасываются в пустое значение.
+type FlightPatchRequest struct {
+	FlightNumber    string     `json:"flight_number"`
+	DepartureDate   time.Time  `json:"departure_date"`
+	AircraftType    *string    `json:"aircraft_type,omitempty"`
+	ArrivalDate     *time.Time `json:"arrival_date,omitempty"`
+	PassengersCount *int       `json:"passengers_count,omitempty"`
+
+	EstimatedDeparture *time.Time `json:"estimated_departure,omitempty"`
+	ActualDeparture    *time.Time `json:"actual_departure,omitempty"`
+	EstimatedArrival   *time.Time `json:"estimated_arrival,omitempty"`
+	ActualArrival      *time.Time `json:"actual_arrival,omitempty"`
+	DelayCodes         []string   `json:"delay_codes,omitempty"`
+	Origin             *string    `json:"origin,omitempty"`
+	Destination        *string    `json:"destination,omitempty"`
+
+	UpdateMask []string `json:"update_mask,omitempty"`
+}
+
+// FlightRefRequest тело POST /api/flights/cancel и /api/flights/restore
+type FlightRefRequest struct {
+	FlightNumber  string    `json:"flight_number"`
+	DepartureDate time.Time `json:"departure_date"`
 }
 
 type FlightRequestData struct {
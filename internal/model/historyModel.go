@@ -0,0 +1,130 @@
+package model
+
+import "time"
+
+// Источники изменений рейса в flight_history
+const (
+	HistorySourceKafka     = "kafka"     // обработка сообщения из входного топика
+	HistorySourceMigration = "migration" // начальная версия рейса, записанного до появления истории
+)
+
+// FlightSnapshot значения изменяемых полей рейса, хранятся в истории как JSONB
+type FlightSnapshot struct {
+	AircraftType    string    `json:"aircraft_type"`
+	ArrivalDate     time.Time `json:"arrival_date"`
+	PassengersCount int       `json:"passengers_count"`
+
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+
+	// Status отсутствует в версиях, записанных до появления операционного статуса
+	Status string `json:"status,omitempty"`
+
+	EstimatedDeparture *time.Time `json:"estimated_departure,omitempty"`
+	ActualDeparture    *time.Time `json:"actual_departure,omitempty"`
+	EstimatedArrival   *time.Time `json:"estimated_arrival,omitempty"`
+	ActualArrival      *time.Time `json:"actual_arrival,omitempty"`
+	DelayCodes         []string   `json:"delay_codes,omitempty"`
+
+	Origin      string `json:"origin,omitempty"`
+	Destination string `json:"destination,omitempty"`
+}
+
+// FlightHistoryEntry запись журнала изменений рейса, пишется в транзакции каждого upsert
+type FlightHistoryEntry struct {
+	ID            int64           `db:"id"`
+	FlightNumber  string          `db:"flight_number"`
+	DepartureDate time.Time       `db:"departure_date"`
+	MetaID        int             `db:"meta_id"`
+	Source        string          `db:"source"`
+	OldValues     *FlightSnapshot `db:"old_values"` // nil, если рейс создан этой записью
+	NewValues     FlightSnapshot  `db:"new_values"`
+	ChangedFields []string        `db:"changed_fields"`
+	ChangedAt     time.Time       `db:"changed_at"`
+
+	// Период, когда версия NewValues была актуальной; ValidTo == nil у текущей версии
+	ValidFrom time.Time  `db:"valid_from"`
+	ValidTo   *time.Time `db:"valid_to"`
+
+	// Заполняется при выборке из flight_meta
+	CreatedBy string `db:"created_by"`
+}
+
+// FlightHistoryResponse история изменений рейса, новые записи первыми
+type FlightHistoryResponse struct {
+	FlightNumber  string
+	DepartureDate time.Time
+	History       []*FlightHistoryEntry
+	Pagination    Pagination
+}
+
+// NewFlightSnapshot снимок полей рейса, для nil возвращает nil
+func NewFlightSnapshot(flight *FlightData) *FlightSnapshot {
+	if flight == nil {
+		return nil
+	}
+	return &FlightSnapshot{
+		AircraftType:    flight.AircraftType,
+		ArrivalDate:     flight.ArrivalDate,
+		PassengersCount: flight.PassengersCount,
+		CancelledAt:     flight.CancelledAt,
+		DeletedAt:       flight.DeletedAt,
+		Status:          flight.Status,
+
+		EstimatedDeparture: flight.EstimatedDeparture,
+		ActualDeparture:    flight.ActualDeparture,
+		EstimatedArrival:   flight.EstimatedArrival,
+		ActualArrival:      flight.ActualArrival,
+		DelayCodes:         flight.DelayCodes,
+
+		Origin:      flight.Origin,
+		Destination: flight.Destination,
+	}
+}
+
+// Field возвращает значение поля снимка по JSON-имени в виде, пригодном для ответа API
+func (s *FlightSnapshot) Field(name string) any {
+	if s == nil {
+		return nil
+	}
+	switch name {
+	case "aircraft_type":
+		return s.AircraftType
+	case "arrival_date":
+		return s.ArrivalDate.Format(time.RFC3339)
+	case "passengers_count":
+		return s.PassengersCount
+	case "cancelled_at":
+		return optionalTime(s.CancelledAt)
+	case "deleted_at":
+		return optionalTime(s.DeletedAt)
+	case "status":
+		return s.Status
+	case "estimated_departure":
+		return optionalTime(s.EstimatedDeparture)
+	case "actual_departure":
+		return optionalTime(s.ActualDeparture)
+	case "estimated_arrival":
+		return optionalTime(s.EstimatedArrival)
+	case "actual_arrival":
+		return optionalTime(s.ActualArrival)
+	case "delay_codes":
+		if s.DelayCodes == nil {
+			return []string{}
+		}
+		return s.DelayCodes
+	case "origin":
+		return s.Origin
+	case "destination":
+		return s.Destination
+	}
+	return nil
+}
+
+// optionalTime отдает nil вместо пустой строки, чтобы в истории было видно снятие отметки
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
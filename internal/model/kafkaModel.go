@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// SpilledMessage сообщение, отложенное в kafka_spill из-за переполнения очереди producer
+type SpilledMessage struct {
+	ID      int64          `db:"id"`
+	MetaID  int            `db:"meta_id"`
+	Request *FlightRequest `db:"payload"`
+}
+
+// KafkaOffset координаты обработанного сообщения для дедупликации в транзакционном режиме
+type KafkaOffset struct {
+	GroupID   string `db:"consumer_group"`
+	Topic     string `db:"topic"`
+	Partition int32  `db:"partition"`
+	Offset    int64  `db:"offset_value"`
+}
+
+// FlightUpdatedEvent событие flight.updated в выходном топике, ключ сообщения - рейс
+type FlightUpdatedEvent struct {
+	Type          string          `json:"type"`
+	MetaID        int             `json:"meta_id"`
+	FlightNumber  string          `json:"flight_number"`
+	DepartureDate time.Time       `json:"departure_date"`
+	Old           *FlightResponse `json:"old"` // nil, если рейс создан этим сообщением
+	New           FlightResponse  `json:"new"`
+	ChangedFields []string        `json:"changed_fields"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// OutboxMessage сообщение, записанное в outbox в транзакции обработки и ожидающее отправки
+type OutboxMessage struct {
+	ID      int64  `db:"id"`
+	Topic   string `db:"topic"`
+	Key     string `db:"message_key"`
+	Payload []byte `db:"payload"`
+}
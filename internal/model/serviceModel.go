@@ -7,6 +7,8 @@ type FlightMeta struct {
 	FlightNumber  string     `db:"flight_number"`
 	DepartureDate time.Time  `db:"departure_date"`
 	Status        string     `db:"status"` // pending, processed, error
+	Error         string     `db:"error"`  // причина отклонения сообщения при статусе error
+	CreatedBy     string     `db:"created_by"`
 	CreatedAt     time.Time  `db:"created_at"`
 	ProcessedAt   *time.Time `db:"processed_at"`
 }
@@ -18,6 +20,49 @@ type FlightData struct {
 	ArrivalDate     time.Time `db:"arrival_date"`
 	PassengersCount int       `db:"passengers_count"`
 	UpdatedAt       time.Time `db:"updated_at"`
+
+	CancelledAt *time.Time `db:"cancelled_at"`
+	DeletedAt   *time.Time `db:"deleted_at"` // удаленный рейс не отдается в GET и поиске
+
+	Status          string     `db:"status"` // операционный статус, см. FlightStatuses
+	StatusChangedAt *time.Time `db:"status_changed_at"`
+
+	// DepartureDate и ArrivalDate - время по расписанию
+	EstimatedDeparture *time.Time `db:"estimated_departure"`
+	ActualDeparture    *time.Time `db:"actual_departure"`
+	EstimatedArrival   *time.Time `db:"estimated_arrival"`
+	ActualArrival      *time.Time `db:"actual_arrival"`
+	DelayCodes         []string   `db:"delay_codes"` // коды IATA AHM 730
+
+	Origin      string `db:"origin"` // IATA код, пустой - маршрут не указан
+	Destination string `db:"destination"`
+}
+
+// DepartureDelayMinutes задержка вылета относительно расписания: по фактическому времени,
+// а до вылета - по расчетному. nil, если ни одно из них не известно.
+func (f *FlightData) DepartureDelayMinutes() *int {
+	return delayMinutes(f.DepartureDate, f.ActualDeparture, f.EstimatedDeparture)
+}
+
+// ArrivalDelayMinutes задержка прилета, считается так же, как задержка вылета
+func (f *FlightData) ArrivalDelayMinutes() *int {
+	if f.ArrivalDate.IsZero() {
+		return nil
+	}
+	return delayMinutes(f.ArrivalDate, f.ActualArrival, f.EstimatedArrival)
+}
+
+// delayMinutes разница с расписанием в целых минутах, ранний вылет дает отрицательное значение
+func delayMinutes(scheduled time.Time, actual, estimated *time.Time) *int {
+	at := actual
+	if at == nil {
+		at = estimated
+	}
+	if at == nil {
+		return nil
+	}
+	minutes := int(at.Sub(scheduled) / time.Minute)
+	return &minutes
 }
 
 type Pagination struct {
@@ -25,6 +70,36 @@ type Pagination struct {
 	Limit int `json:"limit"`
 }
 
+// FlightFilter параметры поиска рейсов, пустые поля не участвуют в фильтрации
+type FlightFilter struct {
+	FlightNumber  string
+	AircraftType  string
+	DepartureFrom time.Time
+	DepartureTo   time.Time
+	Origin        string
+	Destination   string
+	Limit         int
+	Offset        int
+}
+
+type FlightSearchResponse struct {
+	Flights    []*FlightData `json:"flights"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+// LocalFlight рейс со временем вылета в часовом поясе аэропорта вылета
+type LocalFlight struct {
+	*FlightData
+	DepartureLocal time.Time
+}
+
+// FlightDayResponse вылеты рейса за календарную дату по местному времени
+type FlightDayResponse struct {
+	FlightNumber string
+	Date         time.Time // полночь UTC, значима только дата
+	Flights      []*LocalFlight
+}
+
 type FlightMetaResponse struct {
 	FlightNumber string        `json:"flight_number"`
 	Meta         []*FlightMeta `json:"meta"`
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Операционные статусы рейса
+const (
+	FlightStatusScheduled = "scheduled"
+	FlightStatusBoarding  = "boarding"
+	FlightStatusDeparted  = "departed"
+	FlightStatusAirborne  = "airborne"
+	FlightStatusLanded    = "landed"
+	FlightStatusArrived   = "arrived"
+	FlightStatusCancelled = "cancelled"
+	FlightStatusDiverted  = "diverted"
+)
+
+// FlightStatuses все операционные статусы в порядке жизненного цикла рейса
+var FlightStatuses = []string{
+	FlightStatusScheduled, FlightStatusBoarding, FlightStatusDeparted, FlightStatusAirborne,
+	FlightStatusLanded, FlightStatusArrived, FlightStatusCancelled, FlightStatusDiverted,
+}
+
+// FlightStatusRequest тело POST /api/flights/status
+type FlightStatusRequest struct {
+	FlightNumber  string    `json:"flight_number"`
+	DepartureDate time.Time `json:"departure_date"`
+	Status        string    `json:"status"`
+	// OccurredAt время события по данным источника, по умолчанию время приема запроса
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// FlightStatusTransition переход рейса между статусами
+type FlightStatusTransition struct {
+	ID            int64     `db:"id"`
+	FlightNumber  string    `db:"flight_number"`
+	DepartureDate time.Time `db:"departure_date"`
+	MetaID        int       `db:"meta_id"`
+	FromStatus    string    `db:"from_status"`
+	ToStatus      string    `db:"to_status"`
+	OccurredAt    time.Time `db:"occurred_at"`
+	RecordedAt    time.Time `db:"recorded_at"`
+}
+
+// FlightStatusResponse текущий статус рейса и переходы в порядке их записи
+type FlightStatusResponse struct {
+	Flight      *FlightData
+	Transitions []*FlightStatusTransition
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StreamEvent событие потока обновлений рейсов, отдаваемое клиентам через Server-Sent Events.
+// ID монотонно растет и используется клиентом как Last-Event-ID при переподключении.
+type StreamEvent struct {
+	ID            int64           `db:"id"`
+	Type          string          `db:"event_type"`
+	FlightNumber  string          `db:"flight_number"`
+	DepartureDate time.Time       `db:"departure_date"`
+	AircraftType  string          `db:"aircraft_type"`
+	Payload       json.RawMessage `db:"payload"` // FlightUpdatedEvent
+	CreatedAt     time.Time       `db:"created_at"`
+}
+
+// StreamFilter фильтр подписки на поток, пустые поля не участвуют в фильтрации.
+// Диапазон дат как в поиске: DepartureFrom включительно, DepartureTo не включительно.
+type StreamFilter struct {
+	FlightNumber  string
+	AircraftType  string
+	DepartureFrom time.Time
+	DepartureTo   time.Time
+}
+
+// Match проверяет, подходит ли событие под фильтр
+func (f StreamFilter) Match(event *StreamEvent) bool {
+	if f.FlightNumber != "" && f.FlightNumber != event.FlightNumber {
+		return false
+	}
+	if f.AircraftType != "" && f.AircraftType != event.AircraftType {
+		return false
+	}
+	if !f.DepartureFrom.IsZero() && event.DepartureDate.Before(f.DepartureFrom) {
+		return false
+	}
+	if !f.DepartureTo.IsZero() && !event.DepartureDate.Before(f.DepartureTo) {
+		return false
+	}
+	return true
+}
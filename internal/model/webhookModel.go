@@ -0,0 +1,127 @@
+package model
+
+import "time"
+
+// WebhookSubscription подписка партнера на изменения рейсов
+type WebhookSubscription struct {
+	ID                  int        `db:"id"`
+	Owner               string     `db:"owner"`
+	URL                 string     `db:"url"`
+	Secret              string     `db:"secret"`
+	FlightNumber        string     `db:"flight_number"`  // точное совпадение, пусто - любой рейс
+	FlightPattern       string     `db:"flight_pattern"` // шаблон с *, например SU*
+	Enabled             bool       `db:"enabled"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	CreatedAt           time.Time  `db:"created_at"`
+	DisabledAt          *time.Time `db:"disabled_at"`
+}
+
+// WebhookDelivery попытка доставки события подписчику
+type WebhookDelivery struct {
+	ID             int64      `db:"id"`
+	SubscriptionID int        `db:"subscription_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"` // pending, delivered, failed
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	ResponseStatus *int       `db:"response_status"`
+	LastError      string     `db:"last_error"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+
+	// Заполняются при выборке для отправки
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// WebhookRequest тело POST/PUT /api/webhooks
+type WebhookRequest struct {
+	URL           string `json:"url"`
+	FlightNumber  string `json:"flight_number"`
+	FlightPattern string `json:"flight_pattern"`
+	Secret        string `json:"secret"`  // если не задан при создании, генерируется сервером
+	Enabled       *bool  `json:"enabled"` // только для PUT, true сбрасывает счетчик ошибок
+}
+
+// WebhookResponse подписка в ответах API. Secret возвращается только при создании.
+type WebhookResponse struct {
+	ID                  int    `json:"id"`
+	URL                 string `json:"url"`
+	FlightNumber        string `json:"flight_number"`
+	FlightPattern       string `json:"flight_pattern"`
+	Secret              string `json:"secret,omitempty"`
+	Enabled             bool   `json:"enabled"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	CreatedAt           string `json:"created_at"`
+	DisabledAt          string `json:"disabled_at"`
+}
+
+// WebhookListResponse ответ на GET /api/webhooks
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveryItem запись журнала доставки
+type WebhookDeliveryItem struct {
+	ID             int64  `json:"id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status"`
+	LastError      string `json:"last_error"`
+	CreatedAt      string `json:"created_at"`
+	DeliveredAt    string `json:"delivered_at"`
+}
+
+// WebhookDeliveryListResponse ответ на GET /api/webhooks/:id/deliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryItem `json:"deliveries"`
+}
+
+func NewWebhookResponse(sub *WebhookSubscription) WebhookResponse {
+	disabledAt := ""
+	if sub.DisabledAt != nil {
+		disabledAt = sub.DisabledAt.Format(time.RFC3339)
+	}
+	return WebhookResponse{
+		ID:                  sub.ID,
+		URL:                 sub.URL,
+		FlightNumber:        sub.FlightNumber,
+		FlightPattern:       sub.FlightPattern,
+		Enabled:             sub.Enabled,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		CreatedAt:           sub.CreatedAt.Format(time.RFC3339),
+		DisabledAt:          disabledAt,
+	}
+}
+
+func NewWebhookListResponse(subs []*WebhookSubscription) WebhookListResponse {
+	webhooks := make([]WebhookResponse, len(subs))
+	for i, sub := range subs {
+		webhooks[i] = NewWebhookResponse(sub)
+	}
+	return WebhookListResponse{Webhooks: webhooks}
+}
+
+func NewWebhookDeliveryListResponse(deliveries []*WebhookDelivery) WebhookDeliveryListResponse {
+	items := make([]WebhookDeliveryItem, len(deliveries))
+	for i, d := range deliveries {
+		item := WebhookDeliveryItem{
+			ID:        d.ID,
+			EventType: d.EventType,
+			Status:    d.Status,
+			Attempts:  d.Attempts,
+			LastError: d.LastError,
+			CreatedAt: d.CreatedAt.Format(time.RFC3339),
+		}
+		if d.ResponseStatus != nil {
+			item.ResponseStatus = *d.ResponseStatus
+		}
+		if d.DeliveredAt != nil {
+			item.DeliveredAt = d.DeliveredAt.Format(time.RFC3339)
+		}
+		items[i] = item
+	}
+	return WebhookDeliveryListResponse{Deliveries: items}
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit параметры token bucket: скорость пополнения в токенах в секунду и емкость
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result решение лимитера по одному запросу
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // через сколько появится токен, если запрос отклонен
+}
+
+// Limiter token bucket лимитер, ключ определяет отдельное ведро
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Check применяет лимит для пары клиент/маршрут. Ошибка хранилища лимитов не блокирует запрос.
+// Возвращает domain.ErrRateLimited и время до появления токена, если запрос отклонен.
+func Check(ctx context.Context, limiter Limiter, cfg config.RateLimitConfig, clientID, route string) (time.Duration, error) {
+	if !cfg.Enabled {
+		return 0, nil
+	}
+	return allow(ctx, limiter, clientID+"|"+route, route, resolveLimit(cfg, clientID, route))
+}
+
+// CheckIP применяет общий лимит на IP адрес. Вызывается до аутентификации,
+// чтобы поток запросов без учетных данных не доходил до проверки ключей и JWT.
+func CheckIP(ctx context.Context, limiter Limiter, cfg config.RateLimitConfig, ip string) (time.Duration, error) {
+	if !cfg.Enabled {
+		return 0, nil
+	}
+	return allow(ctx, limiter, "ip|"+ip, perIPRoute, Limit{Rate: cfg.PerIP.Rate, Burst: cfg.PerIP.Burst})
+}
+
+// perIPRoute метка метрик для отказов по лимиту на IP
+const perIPRoute = "per_ip"
+
+func allow(ctx context.Context, limiter Limiter, key, route string, limit Limit) (time.Duration, error) {
+	result, err := limiter.Allow(ctx, key, limit)
+	if err != nil {
+		logger.Error("Rate limiter failed, allowing request", zap.String("route", route), zap.Error(err))
+		metrics.RateLimitErrors.Inc()
+		return 0, nil
+	}
+
+	if !result.Allowed {
+		metrics.RateLimitRejections.WithLabelValues(route).Inc()
+		return result.RetryAfter, domain.RateLimited("rate_limited",
+			fmt.Sprintf("rate limit exceeded, retry after %ss", RetryAfterSeconds(result.RetryAfter)))
+	}
+
+	return 0, nil
+}
+
+// RetryAfterSeconds округляет задержку вверх до целых секунд для заголовка Retry-After
+func RetryAfterSeconds(d time.Duration) string {
+	return fmt.Sprintf("%d", int(math.Max(1, math.Ceil(d.Seconds()))))
+}
+
+// resolveLimit выбирает правило: для клиента, затем для маршрута, затем по умолчанию.
+// Ключи clients сравниваются без учета регистра, так как viper приводит их к нижнему.
+func resolveLimit(cfg config.RateLimitConfig, clientID, route string) Limit {
+	rule := cfg.Default
+	for _, r := range cfg.Routes {
+		if r.Route == route {
+			rule = r.Rule()
+			break
+		}
+	}
+	if r, ok := cfg.Clients[strings.ToLower(clientID)]; ok {
+		rule = r
+	}
+	return Limit{Rate: rule.Rate, Burst: rule.Burst}
+}
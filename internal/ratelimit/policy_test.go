@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+
+	"github.com/spf13/viper"
+)
+
+const rateLimitYAML = `
+rate_limit:
+  enabled: true
+  default:
+    rate: 50
+    burst: 100
+  routes:
+    - route: "POST /api/flights"
+      rate: 20
+      burst: 40
+    - route: "/flight.v1.FlightService/GetFlight"
+      rate: 5
+      burst: 10
+  clients:
+    Partner-A:
+      rate: 500
+      burst: 1000
+`
+
+func TestResolveLimit(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(rateLimitYAML)); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	var cfg config.RateLimitConfig
+	if err := v.UnmarshalKey("rate_limit", &cfg); err != nil {
+		t.Fatalf("UnmarshalKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		clientID string
+		route    string
+		want     Limit
+	}{
+		{name: "http route", clientID: "svc", route: "POST /api/flights", want: Limit{Rate: 20, Burst: 40}},
+		{name: "grpc method with dots", clientID: "svc", route: "/flight.v1.FlightService/GetFlight", want: Limit{Rate: 5, Burst: 10}},
+		{name: "grpc method names are case sensitive", clientID: "svc", route: "/flight.v1.flightservice/getflight", want: Limit{Rate: 50, Burst: 100}},
+		{name: "default", clientID: "svc", route: "GET /api/flights", want: Limit{Rate: 50, Burst: 100}},
+		{name: "client overrides route", clientID: "Partner-A", route: "/flight.v1.FlightService/GetFlight", want: Limit{Rate: 500, Burst: 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLimit(cfg, tt.clientID, tt.route); got != tt.want {
+				t.Errorf("resolveLimit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckIP(t *testing.T) {
+	cfg := config.RateLimitConfig{Enabled: true, PerIP: config.RateLimitRule{Rate: 0.001, Burst: 2}}
+	limiter := NewMemoryLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := CheckIP(ctx, limiter, cfg, "203.0.113.7"); err != nil {
+			t.Fatalf("CheckIP() request %d error = %v", i+1, err)
+		}
+	}
+
+	retryAfter, err := CheckIP(ctx, limiter, cfg, "203.0.113.7")
+	if !errors.Is(err, domain.ErrRateLimited) || retryAfter <= 0 {
+		t.Fatalf("CheckIP() over burst = %v, %v, want rate limited", retryAfter, err)
+	}
+
+	// Ведро отдельное для каждого IP и не пересекается с ведрами клиентов
+	if _, err := CheckIP(ctx, limiter, cfg, "203.0.113.8"); err != nil {
+		t.Errorf("CheckIP() another IP error = %v", err)
+	}
+	if _, err := Check(ctx, limiter, config.RateLimitConfig{Enabled: true, Default: cfg.PerIP}, "203.0.113.7", "GET /api/flights"); err != nil {
+		t.Errorf("Check() for the same IP as client error = %v", err)
+	}
+
+	cfg.Enabled = false
+	if _, err := CheckIP(ctx, limiter, cfg, "203.0.113.7"); err != nil {
+		t.Errorf("CheckIP() with rate limiting disabled error = %v", err)
+	}
+}
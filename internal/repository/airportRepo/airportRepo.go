@@ -0,0 +1,122 @@
+package airportRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Константы для таблицы airports
+const (
+	TableAirports   = "airports"
+	ColumnCode      = "code"
+	ColumnName      = "name"
+	ColumnCity      = "city"
+	ColumnCountry   = "country"
+	ColumnTimezone  = "timezone"
+	ColumnLatitude  = "latitude"
+	ColumnLongitude = "longitude"
+	ColumnUpdatedAt = "updated_at"
+
+	// upsertBatchSize строк в одном INSERT при загрузке справочника
+	upsertBatchSize = 500
+)
+
+type airportRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewAirportRepository(db *pgxpool.Pool) repository.AirportRepository {
+	return &airportRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *airportRepository) WithTx(tx pgx.Tx) repository.AirportRepository {
+	return &airportRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *airportRepository) Get(ctx context.Context, code string) (*model.Airport, error) {
+	ctx = repository.WithQueryName(ctx, TableAirports, "get")
+
+	query := r.sq.Select(ColumnCode, ColumnName, ColumnCity, ColumnCountry, ColumnTimezone, ColumnLatitude, ColumnLongitude).
+		From(TableAirports).
+		Where(squirrel.Eq{ColumnCode: code}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	airport := &model.Airport{}
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&airport.Code, &airport.Name, &airport.City, &airport.Country,
+		&airport.Timezone, &airport.Latitude, &airport.Longitude)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("airport_not_found", fmt.Sprintf("airport %s not found", code))
+		}
+		return nil, repository.WrapError(err)
+	}
+
+	return airport, nil
+}
+
+// Upsert добавляет аэропорты и обновляет существующие по коду. Возвращает число записанных строк.
+func (r *airportRepository) Upsert(ctx context.Context, airports []*model.Airport) (int, error) {
+	ctx = repository.WithQueryName(ctx, TableAirports, "upsert")
+
+	written := 0
+	for start := 0; start < len(airports); start += upsertBatchSize {
+		batch := airports[start:min(start+upsertBatchSize, len(airports))]
+
+		query := r.sq.Insert(TableAirports).
+			Columns(ColumnCode, ColumnName, ColumnCity, ColumnCountry, ColumnTimezone, ColumnLatitude, ColumnLongitude).
+			PlaceholderFormat(squirrel.Dollar).
+			Suffix(fmt.Sprintf(`
+				ON CONFLICT (%s)
+				DO UPDATE SET
+					%s = EXCLUDED.%s,
+					%s = EXCLUDED.%s,
+					%s = EXCLUDED.%s,
+					%s = EXCLUDED.%s,
+					%s = EXCLUDED.%s,
+					%s = EXCLUDED.%s,
+					%s = CURRENT_TIMESTAMP
+			`, ColumnCode,
+				ColumnName, ColumnName,
+				ColumnCity, ColumnCity,
+				ColumnCountry, ColumnCountry,
+				ColumnTimezone, ColumnTimezone,
+				ColumnLatitude, ColumnLatitude,
+				ColumnLongitude, ColumnLongitude,
+				ColumnUpdatedAt))
+		for _, airport := range batch {
+			query = query.Values(airport.Code, airport.Name, airport.City, airport.Country, airport.Timezone, airport.Latitude, airport.Longitude)
+		}
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return written, err
+		}
+
+		tag, err := r.db.Exec(ctx, sql, args...)
+		if err != nil {
+			return written, repository.WrapError(err)
+		}
+		written += int(tag.RowsAffected())
+	}
+
+	return written, nil
+}
@@ -0,0 +1,60 @@
+package apiKeyRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Константы для таблицы api_keys
+const (
+	TableAPIKeys    = "api_keys"
+	ColumnClientID  = "client_id"
+	ColumnKeyHash   = "key_hash"
+	ColumnScopes    = "scopes"
+	ColumnRevokedAt = "revoked_at"
+)
+
+type apiKeyRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+// GetByHash возвращает действующий (не отозванный) ключ по его хешу
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	ctx = repository.WithQueryName(ctx, TableAPIKeys, "get_by_hash")
+
+	query := r.sq.Select(ColumnClientID, ColumnScopes).
+		From(TableAPIKeys).
+		Where(squirrel.Eq{ColumnKeyHash: keyHash}).
+		Where(squirrel.Eq{ColumnRevokedAt: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.APIKey{KeyHash: keyHash}
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&key.ClientID, &key.Scopes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("api_key_not_found", "api key not found")
+		}
+		return nil, repository.WrapError(err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const pgUniqueViolation = "23505"
+
+// WrapError переводит ошибки драйвера в ошибки предметной области
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return domain.Conflict("duplicate_record", "record already exists", err)
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) || pgconn.Timeout(err) || errors.Is(err, context.DeadlineExceeded) {
+		return domain.Unavailable("database_unavailable", "database is unavailable", err)
+	}
+
+	return err
+}
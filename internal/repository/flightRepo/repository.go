@@ -3,12 +3,14 @@ package flightRepo
 import (
 	"context"
 	"errors"
+	"flight-service/internal/domain"
 	"flight-service/internal/model"
 	"flight-service/internal/repository"
 	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,19 @@ const (
 	ColumnArrivalDate     = "arrival_date"
 	ColumnPassengersCount = "passengers_count"
 	ColumnUpdatedAt       = "updated_at"
+	ColumnCancelledAt     = "cancelled_at"
+	ColumnDeletedAt       = "deleted_at"
+	ColumnStatus          = "status"
+	ColumnStatusChangedAt = "status_changed_at"
+
+	ColumnEstimatedDeparture = "estimated_departure"
+	ColumnActualDeparture    = "actual_departure"
+	ColumnEstimatedArrival   = "estimated_arrival"
+	ColumnActualArrival      = "actual_arrival"
+	ColumnDelayCodes         = "delay_codes"
+
+	ColumnOrigin      = "origin"
+	ColumnDestination = "destination"
 )
 
 type flightRepository struct {
@@ -43,57 +58,161 @@ func (f *flightRepository) WithTx(tx pgx.Tx) repository.FlightRepository {
 }
 
 func (f *flightRepository) Upsert(ctx context.Context, flight *model.FlightData) error {
+	_, err := f.upsert(repository.WithQueryName(ctx, TableFlights, "upsert"), flight, model.FlightPatchFields)
+	return err
+}
+
+// Patch обновляет у существующего рейса только колонки из mask и возвращает сохраненное состояние.
+// Вызывающий проверяет, что рейс есть: иначе строка вставилась бы с пустыми полями вне маски.
+func (f *flightRepository) Patch(ctx context.Context, flight *model.FlightData, mask []string) (*model.FlightData, error) {
+	return f.upsert(repository.WithQueryName(ctx, TableFlights, "patch"), flight, mask)
+}
+
+// patchColumns соответствие полей маски колонкам таблицы flights
+var patchColumns = map[string]string{
+	model.FlightFieldAircraftType:    ColumnAircraftType,
+	model.FlightFieldArrivalDate:     ColumnArrivalDate,
+	model.FlightFieldPassengersCount: ColumnPassengersCount,
+
+	model.FlightFieldEstimatedDeparture: ColumnEstimatedDeparture,
+	model.FlightFieldActualDeparture:    ColumnActualDeparture,
+	model.FlightFieldEstimatedArrival:   ColumnEstimatedArrival,
+	model.FlightFieldActualArrival:      ColumnActualArrival,
+	model.FlightFieldDelayCodes:         ColumnDelayCodes,
+
+	model.FlightFieldOrigin:      ColumnOrigin,
+	model.FlightFieldDestination: ColumnDestination,
+}
+
+// stateColumns колонки, из которых собирается model.FlightData в Get и upsert
+var stateColumns = []string{ColumnAircraftType, ColumnArrivalDate, ColumnPassengersCount, ColumnUpdatedAt,
+	ColumnCancelledAt, ColumnDeletedAt, ColumnStatus, ColumnStatusChangedAt,
+	ColumnEstimatedDeparture, ColumnActualDeparture, ColumnEstimatedArrival, ColumnActualArrival, ColumnDelayCodes,
+	ColumnOrigin, ColumnDestination}
+
+// stateFields указатели на поля рейса в порядке stateColumns
+func stateFields(flight *model.FlightData) []any {
+	return []any{&flight.AircraftType, &flight.ArrivalDate, &flight.PassengersCount, &flight.UpdatedAt,
+		&flight.CancelledAt, &flight.DeletedAt, &flight.Status, &flight.StatusChangedAt,
+		&flight.EstimatedDeparture, &flight.ActualDeparture, &flight.EstimatedArrival, &flight.ActualArrival, &flight.DelayCodes,
+		&flight.Origin, &flight.Destination}
+}
+
+// upsert строит INSERT ... ON CONFLICT DO UPDATE, в котором SET содержит только колонки из mask и updated_at
+func (f *flightRepository) upsert(ctx context.Context, flight *model.FlightData, mask []string) (*model.FlightData, error) {
+	set := make([]string, 0, len(mask)+1)
+	for _, field := range mask {
+		column, ok := patchColumns[field]
+		if !ok {
+			return nil, domain.Validation("invalid_update_mask", fmt.Sprintf("field %q cannot be updated", field))
+		}
+		set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", ColumnUpdatedAt, ColumnUpdatedAt))
+
+	// delay_codes NOT NULL
+	delayCodes := flight.DelayCodes
+	if delayCodes == nil {
+		delayCodes = []string{}
+	}
+
 	query := f.sq.Insert(TableFlights).
-		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnAircraftType, ColumnArrivalDate, ColumnPassengersCount, ColumnUpdatedAt).
-		Values(flight.FlightNumber, flight.DepartureDate, flight.AircraftType, flight.ArrivalDate, flight.PassengersCount, flight.UpdatedAt).
+		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnAircraftType, ColumnArrivalDate, ColumnPassengersCount, ColumnUpdatedAt,
+			ColumnEstimatedDeparture, ColumnActualDeparture, ColumnEstimatedArrival, ColumnActualArrival, ColumnDelayCodes,
+			ColumnOrigin, ColumnDestination).
+		Values(flight.FlightNumber, flight.DepartureDate, flight.AircraftType, flight.ArrivalDate, flight.PassengersCount, flight.UpdatedAt,
+			flight.EstimatedDeparture, flight.ActualDeparture, flight.EstimatedArrival, flight.ActualArrival, delayCodes,
+			flight.Origin, flight.Destination).
 		PlaceholderFormat(squirrel.Dollar).
-		Suffix(fmt.Sprintf(`
-			ON CONFLICT (%s, %s)
-			DO UPDATE SET
-				%s = EXCLUDED.%s,
-				%s = EXCLUDED.%s,
-				%s = EXCLUDED.%s,
-				%s = EXCLUDED.%s
-		`, ColumnFlightNumber, ColumnDepartureDate,
-			ColumnAircraftType, ColumnAircraftType,
-			ColumnArrivalDate, ColumnArrivalDate,
-			ColumnPassengersCount, ColumnPassengersCount,
-			ColumnUpdatedAt, ColumnUpdatedAt))
+		Suffix(fmt.Sprintf("ON CONFLICT (%s, %s) DO UPDATE SET %s RETURNING %s",
+			ColumnFlightNumber, ColumnDepartureDate, strings.Join(set, ", "), strings.Join(stateColumns, ", ")))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	saved := &model.FlightData{
+		FlightNumber:  flight.FlightNumber,
+		DepartureDate: flight.DepartureDate,
+	}
+	err = f.db.QueryRow(ctx, sql, args...).Scan(stateFields(saved)...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+
+	return saved, nil
+}
+
+// UpdateState записывает отметки отмены и удаления и операционный статус рейса. Данные рейса не меняются.
+func (f *flightRepository) UpdateState(ctx context.Context, flight *model.FlightData) error {
+	ctx = repository.WithQueryName(ctx, TableFlights, "update_state")
+
+	query := f.sq.Update(TableFlights).
+		Set(ColumnCancelledAt, flight.CancelledAt).
+		Set(ColumnDeletedAt, flight.DeletedAt).
+		Set(ColumnStatus, flight.Status).
+		Set(ColumnStatusChangedAt, flight.StatusChangedAt).
+		Set(ColumnUpdatedAt, flight.UpdatedAt).
+		Where(squirrel.Eq{ColumnFlightNumber: flight.FlightNumber, ColumnDepartureDate: flight.DepartureDate}).
+		PlaceholderFormat(squirrel.Dollar)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = f.db.Exec(ctx, sql, args...)
-	return err
+	tag, err := f.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return repository.WrapError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.NotFound("flight_not_found",
+			fmt.Sprintf("flight with number %s and departure date %s not found", flight.FlightNumber, flight.DepartureDate.Format(time.RFC3339)))
+	}
+
+	return nil
 }
 
 func (f *flightRepository) Get(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error) {
-	query := f.sq.Select(ColumnAircraftType, ColumnArrivalDate, ColumnPassengersCount, ColumnUpdatedAt).
+	query := f.sq.Select(stateColumns...).
 		From(TableFlights).
 		Where(squirrel.And{
 			squirrel.Eq{ColumnFlightNumber: flightNumber},
 			squirrel.Eq{ColumnDepartureDate: departureDate},
-		}).PlaceholderFormat(squirrel.Dollar)
+		})
 
-	sql, args, err := query.ToSql()
+	return f.get(repository.WithQueryName(ctx, TableFlights, "get"), query, flightNumber, departureDate)
+}
+
+// GetForUpdate читает рейс с блокировкой строки до конца транзакции: параллельная обработка
+// того же рейса ждет коммита и видит уже новое состояние
+func (f *flightRepository) GetForUpdate(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error) {
+	query := f.sq.Select(stateColumns...).
+		From(TableFlights).
+		Where(squirrel.And{
+			squirrel.Eq{ColumnFlightNumber: flightNumber},
+			squirrel.Eq{ColumnDepartureDate: departureDate},
+		}).
+		Suffix("FOR UPDATE")
+
+	return f.get(repository.WithQueryName(ctx, TableFlights, "get_for_update"), query, flightNumber, departureDate)
+}
+
+func (f *flightRepository) get(ctx context.Context, query squirrel.SelectBuilder, flightNumber string, departureDate time.Time) (*model.FlightData, error) {
+	sql, args, err := query.PlaceholderFormat(squirrel.Dollar).ToSql()
 	if err != nil {
 		return nil, err
 	}
 
 	flight := &model.FlightData{}
-	err = f.db.QueryRow(ctx, sql, args...).Scan(
-		&flight.AircraftType,
-		&flight.ArrivalDate,
-		&flight.PassengersCount,
-		&flight.UpdatedAt,
-	)
+	err = f.db.QueryRow(ctx, sql, args...).Scan(stateFields(flight)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("flight with number %s and departure date %s not found", flightNumber, departureDate.Format(time.RFC3339))
+			return nil, domain.NotFound("flight_not_found",
+				fmt.Sprintf("flight with number %s and departure date %s not found", flightNumber, departureDate.Format(time.RFC3339)))
 		}
-		return nil, err
+		return nil, repository.WrapError(err)
 	}
 
 	// Устанавливаем значения, которые мы знаем из параметров запроса
@@ -102,3 +221,79 @@ func (f *flightRepository) Get(ctx context.Context, flightNumber string, departu
 
 	return flight, nil
 }
+
+func (f *flightRepository) Search(ctx context.Context, filter model.FlightFilter) ([]*model.FlightData, int, error) {
+	// Удаленные рейсы в поиск не попадают
+	conditions := squirrel.And{squirrel.Eq{ColumnDeletedAt: nil}}
+	if filter.FlightNumber != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnFlightNumber: filter.FlightNumber})
+	}
+	if filter.AircraftType != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnAircraftType: filter.AircraftType})
+	}
+	if filter.Origin != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnOrigin: filter.Origin})
+	}
+	if filter.Destination != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnDestination: filter.Destination})
+	}
+	if !filter.DepartureFrom.IsZero() {
+		conditions = append(conditions, squirrel.GtOrEq{ColumnDepartureDate: filter.DepartureFrom})
+	}
+	if !filter.DepartureTo.IsZero() {
+		conditions = append(conditions, squirrel.Lt{ColumnDepartureDate: filter.DepartureTo})
+	}
+
+	query := f.sq.Select(ColumnFlightNumber, ColumnDepartureDate, ColumnAircraftType, ColumnArrivalDate, ColumnPassengersCount, ColumnUpdatedAt, ColumnCancelledAt, ColumnStatus,
+		ColumnEstimatedDeparture, ColumnActualDeparture, ColumnEstimatedArrival, ColumnActualArrival, ColumnDelayCodes,
+		ColumnOrigin, ColumnDestination).
+		From(TableFlights).
+		Where(conditions).
+		OrderBy(ColumnDepartureDate, ColumnFlightNumber).
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := f.db.Query(repository.WithQueryName(ctx, TableFlights, "search"), sql, args...)
+	if err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var flights []*model.FlightData
+	for rows.Next() {
+		flight := &model.FlightData{}
+		err = rows.Scan(&flight.FlightNumber, &flight.DepartureDate, &flight.AircraftType, &flight.ArrivalDate, &flight.PassengersCount, &flight.UpdatedAt, &flight.CancelledAt, &flight.Status,
+			&flight.EstimatedDeparture, &flight.ActualDeparture, &flight.EstimatedArrival, &flight.ActualArrival, &flight.DelayCodes,
+			&flight.Origin, &flight.Destination)
+		if err != nil {
+			return nil, 0, repository.WrapError(err)
+		}
+		flights = append(flights, flight)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+
+	countSql, countArgs, err := f.sq.Select("COUNT(*)").
+		From(TableFlights).
+		Where(conditions).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = f.db.QueryRow(repository.WithQueryName(ctx, TableFlights, "search_count"), countSql, countArgs...).Scan(&total)
+	if err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+
+	return flights, total, nil
+}
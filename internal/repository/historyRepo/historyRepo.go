@@ -0,0 +1,230 @@
+package historyRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// Константы для таблицы flight_history
+const (
+	TableFlightHistory  = "flight_history"
+	ColumnID            = "id"
+	ColumnFlightNumber  = "flight_number"
+	ColumnDepartureDate = "departure_date"
+	ColumnMetaID        = "meta_id"
+	ColumnSource        = "source"
+	ColumnOldValues     = "old_values"
+	ColumnNewValues     = "new_values"
+	ColumnChangedFields = "changed_fields"
+	ColumnChangedAt     = "changed_at"
+	ColumnValidFrom     = "valid_from"
+	ColumnValidTo       = "valid_to"
+)
+
+type historyRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewHistoryRepository(db *pgxpool.Pool) repository.HistoryRepository {
+	return &historyRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *historyRepository) WithTx(tx pgx.Tx) repository.HistoryRepository {
+	return &historyRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+// Add записывает новую версию рейса и закрывает предыдущую: ее valid_to становится valid_from новой.
+// Время берется из clock_timestamp() уже после блокировки строки рейса (GetForUpdate или upsert),
+// поэтому версии одного рейса не пересекаются даже при параллельной обработке.
+func (r *historyRepository) Add(ctx context.Context, entry *model.FlightHistoryEntry) error {
+	flightCondition := squirrel.Eq{ColumnFlightNumber: entry.FlightNumber, ColumnDepartureDate: entry.DepartureDate}
+
+	closeQuery := r.sq.Update(TableFlightHistory).
+		Set(ColumnValidTo, squirrel.Expr("clock_timestamp()")).
+		Where(flightCondition).
+		Where(squirrel.Eq{ColumnValidTo: nil}).
+		Suffix("RETURNING " + ColumnValidTo).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := closeQuery.ToSql()
+	if err != nil {
+		return err
+	}
+
+	// Для нового рейса закрывать нечего, версия начинается с текущего момента
+	var validFrom pgtype.Timestamptz
+	err = r.db.QueryRow(repository.WithQueryName(ctx, TableFlightHistory, "close_version"), sql, args...).Scan(&validFrom)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return repository.WrapError(err)
+	}
+
+	// nil срез pgx записал бы как NULL
+	changedFields := entry.ChangedFields
+	if changedFields == nil {
+		changedFields = []string{}
+	}
+
+	query := r.sq.Insert(TableFlightHistory).
+		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnMetaID, ColumnSource,
+			ColumnOldValues, ColumnNewValues, ColumnChangedFields, ColumnChangedAt, ColumnValidFrom).
+		Values(entry.FlightNumber, entry.DepartureDate, entry.MetaID, entry.Source,
+			entry.OldValues, entry.NewValues, changedFields, entry.ChangedAt,
+			squirrel.Expr("COALESCE(?::timestamptz, clock_timestamp())", validFrom)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err = query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(repository.WithQueryName(ctx, TableFlightHistory, "add"), sql, args...); err != nil {
+		return repository.WrapError(err)
+	}
+
+	return nil
+}
+
+// List возвращает историю рейса, новые записи первыми, и общее количество записей
+func (r *historyRepository) List(ctx context.Context, flightNumber string, departureDate time.Time, limit int) ([]*model.FlightHistoryEntry, int, error) {
+	flightCondition := squirrel.And{
+		squirrel.Eq{"h." + ColumnFlightNumber: flightNumber},
+		squirrel.Eq{"h." + ColumnDepartureDate: departureDate},
+	}
+
+	query := r.sq.Select("h."+ColumnID, "h."+ColumnFlightNumber, "h."+ColumnDepartureDate, "h."+ColumnMetaID,
+		"h."+ColumnSource, "h."+ColumnOldValues, "h."+ColumnNewValues, "h."+ColumnChangedFields,
+		"h."+ColumnChangedAt, "h."+ColumnValidFrom, "h."+ColumnValidTo, "m.created_by").
+		From(TableFlightHistory + " h").
+		LeftJoin("flight_meta m ON m.id = h." + ColumnMetaID).
+		Where(flightCondition).
+		OrderBy("h." + ColumnID + " DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(repository.WithQueryName(ctx, TableFlightHistory, "list"), sql, args...)
+	if err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var entries []*model.FlightHistoryEntry
+	for rows.Next() {
+		entry := &model.FlightHistoryEntry{}
+		var createdBy pgtype.Text
+		var validTo pgtype.Timestamptz
+
+		err = rows.Scan(&entry.ID, &entry.FlightNumber, &entry.DepartureDate, &entry.MetaID, &entry.Source,
+			&entry.OldValues, &entry.NewValues, &entry.ChangedFields, &entry.ChangedAt,
+			&entry.ValidFrom, &validTo, &createdBy)
+		if err != nil {
+			return nil, 0, repository.WrapError(err)
+		}
+		entry.CreatedBy = createdBy.String
+		if validTo.Valid {
+			entry.ValidTo = &validTo.Time
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+
+	countQuery := r.sq.Select("COUNT(*)").
+		From(TableFlightHistory + " h").
+		Where(flightCondition).
+		PlaceholderFormat(squirrel.Dollar)
+
+	countSql, countArgs, err := countQuery.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = r.db.QueryRow(repository.WithQueryName(ctx, TableFlightHistory, "count"), countSql, countArgs...).Scan(&total)
+	if err != nil {
+		return nil, 0, repository.WrapError(err)
+	}
+
+	return entries, total, nil
+}
+
+// GetAsOf возвращает рейс в том виде, в каком он был известен сервису в момент asOf.
+// Версии берутся из flight_history по периоду действия valid_from/valid_to.
+func (r *historyRepository) GetAsOf(ctx context.Context, flightNumber string, departureDate time.Time, asOf time.Time) (*model.FlightData, error) {
+	ctx = repository.WithQueryName(ctx, TableFlightHistory, "get_as_of")
+
+	query := r.sq.Select(ColumnNewValues, ColumnChangedAt).
+		From(TableFlightHistory).
+		Where(squirrel.And{
+			squirrel.Eq{ColumnFlightNumber: flightNumber},
+			squirrel.Eq{ColumnDepartureDate: departureDate},
+			squirrel.LtOrEq{ColumnValidFrom: asOf},
+			squirrel.Or{squirrel.Eq{ColumnValidTo: nil}, squirrel.Gt{ColumnValidTo: asOf}},
+		}).PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot model.FlightSnapshot
+	var changedAt time.Time
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&snapshot, &changedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("flight_not_found",
+				fmt.Sprintf("flight with number %s and departure date %s was not known at %s",
+					flightNumber, departureDate.Format(time.RFC3339), asOf.Format(time.RFC3339)))
+		}
+		return nil, repository.WrapError(err)
+	}
+
+	// Версии до появления операционного статуса его не содержат
+	status := snapshot.Status
+	if status == "" {
+		status = model.FlightStatusScheduled
+	}
+
+	return &model.FlightData{
+		AircraftType:    snapshot.AircraftType,
+		FlightNumber:    flightNumber,
+		DepartureDate:   departureDate,
+		ArrivalDate:     snapshot.ArrivalDate,
+		PassengersCount: snapshot.PassengersCount,
+		UpdatedAt:       changedAt,
+		CancelledAt:     snapshot.CancelledAt,
+		DeletedAt:       snapshot.DeletedAt,
+		Status:          status,
+
+		EstimatedDeparture: snapshot.EstimatedDeparture,
+		ActualDeparture:    snapshot.ActualDeparture,
+		EstimatedArrival:   snapshot.EstimatedArrival,
+		ActualArrival:      snapshot.ActualArrival,
+		DelayCodes:         snapshot.DelayCodes,
+
+		Origin:      snapshot.Origin,
+		Destination: snapshot.Destination,
+	}, nil
+}
@@ -0,0 +1,344 @@
+package historyRepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fakeRow отдает заранее заданные значения в Scan
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[i]))
+	}
+	return nil
+}
+
+// fakeQueryRunner запоминает последний запрос
+type fakeQueryRunner struct {
+	row  fakeRow
+	sql  string
+	args []any
+}
+
+func (q *fakeQueryRunner) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (q *fakeQueryRunner) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	q.sql, q.args = sql, args
+	return q.row
+}
+
+func (q *fakeQueryRunner) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("not implemented")
+}
+
+func TestGetAsOfQuery(t *testing.T) {
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	asOf := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	changedAt := asOf.Add(-time.Hour)
+
+	db := &fakeQueryRunner{row: fakeRow{values: []any{
+		model.FlightSnapshot{AircraftType: "A320", PassengersCount: 150, Origin: "SVO"},
+		changedAt,
+	}}}
+	r := &historyRepository{db: db, sq: squirrel.StatementBuilder}
+
+	flight, err := r.GetAsOf(context.Background(), "SU100", departure, asOf)
+	if err != nil {
+		t.Fatalf("GetAsOf() error = %v", err)
+	}
+
+	// Версия действует с valid_from включительно до valid_to не включительно, NULL означает текущую версию
+	wantWhere := "valid_from <= $3 AND (valid_to IS NULL OR valid_to > $4)"
+	if !strings.Contains(db.sql, wantWhere) {
+		t.Errorf("query = %s, want condition %s", db.sql, wantWhere)
+	}
+	if want := []any{"SU100", departure, asOf, asOf}; !reflect.DeepEqual(db.args, want) {
+		t.Errorf("args = %v, want %v", db.args, want)
+	}
+
+	// Версии без статуса записаны до появления операционного статуса
+	if flight.Status != model.FlightStatusScheduled {
+		t.Errorf("Status = %q, want %q", flight.Status, model.FlightStatusScheduled)
+	}
+	if flight.FlightNumber != "SU100" || !flight.DepartureDate.Equal(departure) || !flight.UpdatedAt.Equal(changedAt) ||
+		flight.AircraftType != "A320" || flight.PassengersCount != 150 || flight.Origin != "SVO" {
+		t.Errorf("flight = %+v", flight)
+	}
+}
+
+func TestGetAsOfNotKnown(t *testing.T) {
+	r := &historyRepository{db: &fakeQueryRunner{row: fakeRow{err: pgx.ErrNoRows}}, sq: squirrel.StatementBuilder}
+
+	_, err := r.GetAsOf(context.Background(), "SU100", time.Now(), time.Now())
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("GetAsOf() error = %v, want not found", err)
+	}
+}
+
+// Тесты ниже работают с Postgres из TEST_DATABASE_DSN и применяют миграции в отдельной схеме
+const testDSNEnv = "TEST_DATABASE_DSN"
+
+func TestGetAsOfBoundaries(t *testing.T) {
+	pool := testPool(t)
+	migrate(t, pool, "")
+	ctx := context.Background()
+	r := &historyRepository{db: pool, sq: squirrel.StatementBuilder}
+
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	v1From := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	v2From := v1From.Add(2 * time.Hour)
+
+	insertVersion(t, pool, departure, "A320", v1From, &v2From)
+	insertVersion(t, pool, departure, "B737", v2From, nil)
+
+	tests := []struct {
+		name     string
+		asOf     time.Time
+		want     string
+		notFound bool
+	}{
+		{name: "before the first version", asOf: v1From.Add(-time.Microsecond), notFound: true},
+		{name: "valid_from is inclusive", asOf: v1From, want: "A320"},
+		{name: "inside the first version", asOf: v1From.Add(time.Hour), want: "A320"},
+		{name: "valid_to is exclusive", asOf: v2From, want: "B737"},
+		{name: "just before valid_to", asOf: v2From.Add(-time.Microsecond), want: "A320"},
+		{name: "current version without valid_to", asOf: v2From.AddDate(1, 0, 0), want: "B737"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flight, err := r.GetAsOf(ctx, "SU100", departure, tt.asOf)
+			if tt.notFound {
+				if !errors.Is(err, domain.ErrNotFound) {
+					t.Fatalf("GetAsOf() error = %v, want not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAsOf() error = %v", err)
+			}
+			if flight.AircraftType != tt.want {
+				t.Errorf("AircraftType = %q, want %q", flight.AircraftType, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddClosesCurrentVersion(t *testing.T) {
+	pool := testPool(t)
+	migrate(t, pool, "")
+	ctx := context.Background()
+	r := &historyRepository{db: pool, sq: squirrel.StatementBuilder}
+
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	for _, aircraft := range []string{"A320", "B737"} {
+		err := r.Add(ctx, &model.FlightHistoryEntry{
+			FlightNumber:  "SU100",
+			DepartureDate: departure,
+			Source:        "api",
+			NewValues:     model.FlightSnapshot{AircraftType: aircraft},
+			ChangedAt:     time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Add(%s) error = %v", aircraft, err)
+		}
+	}
+
+	entries, _, err := r.List(ctx, "SU100", departure, 10)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	current, previous := entries[0], entries[1]
+	if current.ValidTo != nil {
+		t.Errorf("current valid_to = %v, want NULL", current.ValidTo)
+	}
+	if previous.ValidTo == nil || !previous.ValidTo.Equal(current.ValidFrom) {
+		t.Errorf("previous valid_to = %v, want %v", previous.ValidTo, current.ValidFrom)
+	}
+
+	flight, err := r.GetAsOf(ctx, "SU100", departure, time.Now())
+	if err != nil || flight.AircraftType != "B737" {
+		t.Errorf("GetAsOf(now) = %+v, %v, want current version B737", flight, err)
+	}
+}
+
+func TestValidityMigrationBackfill(t *testing.T) {
+	pool := testPool(t)
+	migrate(t, pool, "20261019170000")
+	ctx := context.Background()
+
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	changedAt := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+
+	// SU100 записан до появления истории, у SU200 уже есть запись журнала
+	for _, flightNumber := range []string{"SU100", "SU200"} {
+		if _, err := pool.Exec(ctx, `INSERT INTO flights (flight_number, departure_date, aircraft_type, arrival_date, passengers_count, updated_at)
+			VALUES ($1, $2, 'A320', $3, 150, $4)`, flightNumber, departure, departure.Add(2*time.Hour), updatedAt); err != nil {
+			t.Fatalf("failed to insert flight %s: %v", flightNumber, err)
+		}
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO flight_history (flight_number, departure_date, meta_id, source, new_values, changed_fields, changed_at)
+		VALUES ('SU200', $1, 1, 'api', '{"aircraft_type": "B737"}', '{}', $2)`, departure, changedAt); err != nil {
+		t.Fatalf("failed to insert history: %v", err)
+	}
+
+	migrate(t, pool, "20261019180000")
+
+	r := &historyRepository{db: pool, sq: squirrel.StatementBuilder}
+
+	var source string
+	var validFrom time.Time
+	var validTo *time.Time
+	err := pool.QueryRow(ctx, `SELECT source, valid_from, valid_to FROM flight_history WHERE flight_number = 'SU100'`).
+		Scan(&source, &validFrom, &validTo)
+	if err != nil {
+		t.Fatalf("backfilled version of SU100: %v", err)
+	}
+	if source != "migration" || !validFrom.Equal(updatedAt) || validTo != nil {
+		t.Errorf("SU100 version = %s from %v to %v, want migration from %v to NULL", source, validFrom, validTo, updatedAt)
+	}
+
+	flight, err := r.GetAsOf(ctx, "SU100", departure, updatedAt)
+	if err != nil || flight.AircraftType != "A320" || flight.PassengersCount != 150 {
+		t.Errorf("GetAsOf(SU100, updated_at) = %+v, %v, want the migrated version", flight, err)
+	}
+	if _, err := r.GetAsOf(ctx, "SU100", departure, updatedAt.Add(-time.Second)); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetAsOf(SU100, before updated_at) error = %v, want not found", err)
+	}
+
+	// Рейс с историей не получает версию из миграции, его запись действует с changed_at
+	var count int
+	if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM flight_history WHERE flight_number = 'SU200'`).Scan(&count); err != nil {
+		t.Fatalf("count SU200 history: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("SU200 history = %d entries, want 1", count)
+	}
+	flight, err = r.GetAsOf(ctx, "SU200", departure, changedAt)
+	if err != nil || flight.AircraftType != "B737" {
+		t.Errorf("GetAsOf(SU200, changed_at) = %+v, %v, want B737", flight, err)
+	}
+}
+
+func insertVersion(t *testing.T, pool *pgxpool.Pool, departure time.Time, aircraft string, validFrom time.Time, validTo *time.Time) {
+	t.Helper()
+	_, err := pool.Exec(context.Background(), `INSERT INTO flight_history
+		(flight_number, departure_date, meta_id, source, new_values, changed_fields, changed_at, valid_from, valid_to)
+		VALUES ('SU100', $1, 1, 'api', jsonb_build_object('aircraft_type', $2::text), '{}', $3, $3, $4)`,
+		departure, aircraft, validFrom, validTo)
+	if err != nil {
+		t.Fatalf("failed to insert version %s: %v", aircraft, err)
+	}
+}
+
+// testPool подключается к тестовой базе в новой схеме, которая удаляется после теста
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+	ctx := context.Background()
+
+	schema := fmt.Sprintf("test_history_%d", time.Now().UnixNano())
+	admin, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer admin.Close(ctx)
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", testDSNEnv, err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+
+	t.Cleanup(func() {
+		pool.Close()
+		conn, err := pgx.Connect(context.Background(), dsn)
+		if err != nil {
+			return
+		}
+		defer conn.Close(context.Background())
+		_, _ = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+	return pool
+}
+
+// migrate применяет секции Up миграций по версию upTo включительно, пустая upTo - все.
+// Уже примененные версии отмечаются в таблице test_migrations.
+func migrate(t *testing.T, pool *pgxpool.Pool, upTo string) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS test_migrations (version TEXT PRIMARY KEY)"); err != nil {
+		t.Fatalf("failed to create test_migrations: %v", err)
+	}
+
+	files, err := filepath.Glob("../../../migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("migrations not found: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version, _, _ := strings.Cut(filepath.Base(file), "_")
+		if upTo != "" && version > upTo {
+			break
+		}
+
+		tag, err := pool.Exec(ctx, "INSERT INTO test_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING", version)
+		if err != nil {
+			t.Fatalf("failed to record migration %s: %v", version, err)
+		}
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		if _, err := pool.Exec(ctx, up); err != nil {
+			t.Fatalf("failed to apply %s: %v", filepath.Base(file), err)
+		}
+	}
+}
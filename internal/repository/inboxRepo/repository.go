@@ -0,0 +1,56 @@
+package inboxRepo
+
+import (
+	"context"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Константы для таблицы kafka_inbox
+const (
+	TableKafkaInbox = "kafka_inbox"
+	ColumnMetaID    = "meta_id"
+)
+
+type inboxRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewInboxRepository(db *pgxpool.Pool) repository.InboxRepository {
+	return &inboxRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *inboxRepository) WithTx(tx pgx.Tx) repository.InboxRepository {
+	return &inboxRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *inboxRepository) Claim(ctx context.Context, metaID int) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableKafkaInbox, "claim")
+
+	query := r.sq.Insert(TableKafkaInbox).
+		Columns(ColumnMetaID).
+		Values(metaID).
+		Suffix("ON CONFLICT (" + ColumnMetaID + ") DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, repository.WrapError(err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -19,6 +19,8 @@ const (
 	ColumnStatus        = "status"
 	ColumnCreatedAt     = "created_at"
 	ColumnProcessedAt   = "processed_at"
+	ColumnCreatedBy     = "created_by"
+	ColumnError         = "error"
 )
 
 type metaRepository struct {
@@ -41,9 +43,11 @@ func (r *metaRepository) WithTx(tx pgx.Tx) repository.MetaRepository {
 }
 
 func (r *metaRepository) Create(ctx context.Context, meta *model.FlightMeta) (int, error) {
+	ctx = repository.WithQueryName(ctx, TableFlightMeta, "create")
+
 	query := r.sq.Insert(TableFlightMeta).
-		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnStatus).
-		Values(meta.FlightNumber, meta.DepartureDate, "pending").
+		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnStatus, ColumnCreatedBy).
+		Values(meta.FlightNumber, meta.DepartureDate, "pending", pgtype.Text{String: meta.CreatedBy, Valid: meta.CreatedBy != ""}).
 		Suffix("RETURNING " + ColumnID).
 		PlaceholderFormat(squirrel.Dollar)
 
@@ -55,13 +59,15 @@ func (r *metaRepository) Create(ctx context.Context, meta *model.FlightMeta) (in
 	var id int
 	err = r.db.QueryRow(ctx, sql, args...).Scan(&id)
 	if err != nil {
-		return 0, err
+		return 0, repository.WrapError(err)
 	}
 
 	return id, nil
 }
 
 func (r *metaRepository) UpdateStatus(ctx context.Context, id int, status string) error {
+	ctx = repository.WithQueryName(ctx, TableFlightMeta, "update_status")
+
 	query := r.sq.Update(TableFlightMeta).
 		Set(ColumnStatus, status).
 		Set(ColumnProcessedAt, squirrel.Expr("CURRENT_TIMESTAMP")).
@@ -74,12 +80,31 @@ func (r *metaRepository) UpdateStatus(ctx context.Context, id int, status string
 	}
 
 	_, err = r.db.Exec(ctx, sql, args...)
-	return err
+	return repository.WrapError(err)
+}
+
+func (r *metaRepository) Reject(ctx context.Context, id int, reason string) error {
+	ctx = repository.WithQueryName(ctx, TableFlightMeta, "reject")
+
+	query := r.sq.Update(TableFlightMeta).
+		Set(ColumnStatus, "error").
+		Set(ColumnError, reason).
+		Set(ColumnProcessedAt, squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{ColumnID: id}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, sql, args...)
+	return repository.WrapError(err)
 }
 
 func (r *metaRepository) GetByFlightNumber(ctx context.Context, flightNumber string, status string, limit int, offset int) ([]*model.FlightMeta, int, error) {
 	// Основной запрос на получение данных
-	baseQuery := r.sq.Select(ColumnID, ColumnFlightNumber, ColumnDepartureDate, ColumnStatus, ColumnCreatedAt, ColumnProcessedAt).
+	baseQuery := r.sq.Select(ColumnID, ColumnFlightNumber, ColumnDepartureDate, ColumnStatus, ColumnCreatedAt, ColumnProcessedAt, ColumnCreatedBy, ColumnError).
 		From(TableFlightMeta).
 		Where(squirrel.Eq{ColumnFlightNumber: flightNumber}).
 		OrderBy(ColumnCreatedAt + " DESC").
@@ -97,9 +122,9 @@ func (r *metaRepository) GetByFlightNumber(ctx context.Context, flightNumber str
 		return nil, 0, err
 	}
 
-	rows, err := r.db.Query(ctx, sql, args...)
+	rows, err := r.db.Query(repository.WithQueryName(ctx, TableFlightMeta, "get_by_flight_number"), sql, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, repository.WrapError(err)
 	}
 	defer rows.Close()
 
@@ -107,16 +132,19 @@ func (r *metaRepository) GetByFlightNumber(ctx context.Context, flightNumber str
 	for rows.Next() {
 		meta := &model.FlightMeta{}
 		var processedAt pgtype.Timestamp
+		var createdBy, metaErr pgtype.Text
 
-		err = rows.Scan(&meta.ID, &meta.FlightNumber, &meta.DepartureDate, &meta.Status, &meta.CreatedAt, &processedAt)
+		err = rows.Scan(&meta.ID, &meta.FlightNumber, &meta.DepartureDate, &meta.Status, &meta.CreatedAt, &processedAt, &createdBy, &metaErr)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, repository.WrapError(err)
 		}
 		if processedAt.Valid {
 			meta.ProcessedAt = &processedAt.Time
 		} else {
 			meta.ProcessedAt = nil
 		}
+		meta.CreatedBy = createdBy.String
+		meta.Error = metaErr.String
 
 		metas = append(metas, meta)
 	}
@@ -137,9 +165,9 @@ func (r *metaRepository) GetByFlightNumber(ctx context.Context, flightNumber str
 	}
 
 	var total int
-	err = r.db.QueryRow(ctx, countSql, countArgs...).Scan(&total)
+	err = r.db.QueryRow(repository.WithQueryName(ctx, TableFlightMeta, "count_by_flight_number"), countSql, countArgs...).Scan(&total)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, repository.WrapError(err)
 	}
 
 	return metas, total, nil
@@ -147,6 +175,8 @@ func (r *metaRepository) GetByFlightNumber(ctx context.Context, flightNumber str
 
 // GetStatusCounts возвращает количество записей по каждому статусу
 func (r *metaRepository) GetStatusCounts(ctx context.Context) (map[string]int, error) {
+	ctx = repository.WithQueryName(ctx, TableFlightMeta, "status_counts")
+
 	query := r.sq.Select(ColumnStatus, "COUNT(*) as count").
 		From(TableFlightMeta).
 		GroupBy(ColumnStatus).
@@ -159,7 +189,7 @@ func (r *metaRepository) GetStatusCounts(ctx context.Context) (map[string]int, e
 
 	rows, err := r.db.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, repository.WrapError(err)
 	}
 	defer rows.Close()
 
@@ -169,7 +199,7 @@ func (r *metaRepository) GetStatusCounts(ctx context.Context) (map[string]int, e
 		var count int
 		err := rows.Scan(&status, &count)
 		if err != nil {
-			return nil, err
+			return nil, repository.WrapError(err)
 		}
 		statusCounts[status] = count
 	}
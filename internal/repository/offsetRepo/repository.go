@@ -0,0 +1,131 @@
+package offsetRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Константы для таблицы processed_offsets
+const (
+	TableProcessedOffsets = "processed_offsets"
+	ColumnConsumerGroup   = "consumer_group"
+	ColumnTopic           = "topic"
+	ColumnPartition       = "partition"
+	ColumnOffset          = "offset_value"
+	ColumnProcessedAt     = "processed_at"
+	ColumnEventTopic      = "event_topic"
+	ColumnEventKey        = "event_key"
+	ColumnEventPayload    = "event_payload"
+)
+
+type offsetRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewOffsetRepository(db *pgxpool.Pool) repository.OffsetRepository {
+	return &offsetRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *offsetRepository) WithTx(tx pgx.Tx) repository.OffsetRepository {
+	return &offsetRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+// MarkProcessed сдвигает offset партиции вперед и сбрасывает событие прошлого сообщения.
+// Сообщения партиции обрабатываются по порядку, поэтому offset не больше сохраненного
+// означает повторную доставку.
+func (r *offsetRepository) MarkProcessed(ctx context.Context, offset model.KafkaOffset) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableProcessedOffsets, "mark_processed")
+
+	query := r.sq.Insert(TableProcessedOffsets).
+		Columns(ColumnConsumerGroup, ColumnTopic, ColumnPartition, ColumnOffset).
+		Values(offset.GroupID, offset.Topic, offset.Partition, offset.Offset).
+		Suffix("ON CONFLICT (" + ColumnConsumerGroup + ", " + ColumnTopic + ", " + ColumnPartition + ") DO UPDATE SET " +
+			ColumnOffset + " = EXCLUDED." + ColumnOffset + ", " +
+			ColumnProcessedAt + " = CURRENT_TIMESTAMP, " +
+			ColumnEventTopic + " = NULL, " + ColumnEventKey + " = NULL, " + ColumnEventPayload + " = NULL " +
+			"WHERE " + TableProcessedOffsets + "." + ColumnOffset + " < EXCLUDED." + ColumnOffset).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, repository.WrapError(err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// SaveEvent запоминает событие, отправленное в Kafka транзакции обработки offset
+func (r *offsetRepository) SaveEvent(ctx context.Context, offset model.KafkaOffset, event *model.OutboxMessage) error {
+	ctx = repository.WithQueryName(ctx, TableProcessedOffsets, "save_event")
+
+	query := r.sq.Update(TableProcessedOffsets).
+		Set(ColumnEventTopic, event.Topic).
+		Set(ColumnEventKey, event.Key).
+		Set(ColumnEventPayload, event.Payload).
+		Where(offsetCondition(offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return repository.WrapError(err)
+	}
+
+	return nil
+}
+
+// GetEvent возвращает событие, сохраненное при обработке offset, или nil, если его не было
+func (r *offsetRepository) GetEvent(ctx context.Context, offset model.KafkaOffset) (*model.OutboxMessage, error) {
+	ctx = repository.WithQueryName(ctx, TableProcessedOffsets, "get_event")
+
+	query := r.sq.Select(ColumnEventTopic, ColumnEventKey, ColumnEventPayload).
+		From(TableProcessedOffsets).
+		Where(offsetCondition(offset)).
+		Where(squirrel.NotEq{ColumnEventPayload: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &model.OutboxMessage{}
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&event.Topic, &event.Key, &event.Payload)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+
+	return event, nil
+}
+
+func offsetCondition(offset model.KafkaOffset) squirrel.Eq {
+	return squirrel.Eq{
+		ColumnConsumerGroup: offset.GroupID,
+		ColumnTopic:         offset.Topic,
+		ColumnPartition:     offset.Partition,
+		ColumnOffset:        offset.Offset,
+	}
+}
@@ -0,0 +1,125 @@
+package outboxRepo
+
+import (
+	"context"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Константы для таблицы outbox
+const (
+	TableOutbox   = "outbox"
+	ColumnID      = "id"
+	ColumnTopic   = "topic"
+	ColumnKey     = "message_key"
+	ColumnPayload = "payload"
+)
+
+// relayLockID ключ advisory lock отправителя outbox
+const relayLockID int64 = 0x6f7574626f78
+
+type outboxRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) repository.OutboxRepository {
+	return &outboxRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *outboxRepository) WithTx(tx pgx.Tx) repository.OutboxRepository {
+	return &outboxRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *outboxRepository) Add(ctx context.Context, topic, key string, payload any) error {
+	ctx = repository.WithQueryName(ctx, TableOutbox, "add")
+
+	query := r.sq.Insert(TableOutbox).
+		Columns(ColumnTopic, ColumnKey, ColumnPayload).
+		Values(topic, key, payload).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return repository.WrapError(err)
+	}
+
+	return nil
+}
+
+// TryLockRelay берет транзакционный advisory lock: outbox отправляет одна реплика за раз,
+// иначе события одного рейса из разных пачек могли бы попасть в Kafka не по порядку
+func (r *outboxRepository) TryLockRelay(ctx context.Context) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableOutbox, "try_lock_relay")
+
+	var locked bool
+	if err := r.db.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", relayLockID).Scan(&locked); err != nil {
+		return false, repository.WrapError(err)
+	}
+	return locked, nil
+}
+
+// FetchPending возвращает самые старые сообщения в порядке записи
+func (r *outboxRepository) FetchPending(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	ctx = repository.WithQueryName(ctx, TableOutbox, "fetch_pending")
+
+	query := r.sq.Select(ColumnID, ColumnTopic, ColumnKey, ColumnPayload).
+		From(TableOutbox).
+		OrderBy(ColumnID).
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var messages []*model.OutboxMessage
+	for rows.Next() {
+		msg := &model.OutboxMessage{}
+		if err := rows.Scan(&msg.ID, &msg.Topic, &msg.Key, &msg.Payload); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, ids []int64) error {
+	ctx = repository.WithQueryName(ctx, TableOutbox, "delete")
+
+	query := r.sq.Delete(TableOutbox).
+		Where(squirrel.Eq{ColumnID: ids}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return repository.WrapError(err)
+	}
+
+	return nil
+}
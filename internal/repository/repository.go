@@ -18,12 +18,124 @@ type MetaRepository interface {
 	WithTx(tx pgx.Tx) MetaRepository
 	Create(ctx context.Context, meta *model.FlightMeta) (int, error)
 	UpdateStatus(ctx context.Context, id int, status string) error
+	// Reject переводит запись в статус error с причиной отклонения сообщения
+	Reject(ctx context.Context, id int, reason string) error
 	GetStatusCounts(ctx context.Context) (map[string]int, error)
 	GetByFlightNumber(ctx context.Context, flightNumber string, status string, limit int, offset int) ([]*model.FlightMeta, int, error)
 }
 
 type FlightRepository interface {
 	WithTx(tx pgx.Tx) FlightRepository
+	// Upsert записывает данные рейса, не затрагивая отметки отмены и удаления
 	Upsert(ctx context.Context, flight *model.FlightData) error
+	// Patch обновляет у рейса только колонки из mask и возвращает сохраненное состояние
+	Patch(ctx context.Context, flight *model.FlightData, mask []string) (*model.FlightData, error)
+	// UpdateState записывает отметки отмены и удаления и операционный статус рейса
+	UpdateState(ctx context.Context, flight *model.FlightData) error
 	Get(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error)
+	// GetForUpdate читает рейс и блокирует его строку до конца транзакции
+	GetForUpdate(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error)
+	Search(ctx context.Context, filter model.FlightFilter) ([]*model.FlightData, int, error)
+}
+
+// HistoryRepository журнал изменений рейсов, пишется в транзакции upsert
+type HistoryRepository interface {
+	WithTx(tx pgx.Tx) HistoryRepository
+	Add(ctx context.Context, entry *model.FlightHistoryEntry) error
+	List(ctx context.Context, flightNumber string, departureDate time.Time, limit int) ([]*model.FlightHistoryEntry, int, error)
+	// GetAsOf возвращает версию рейса, актуальную в момент asOf
+	GetAsOf(ctx context.Context, flightNumber string, departureDate time.Time, asOf time.Time) (*model.FlightData, error)
+}
+
+// AirportRepository справочник аэропортов
+type AirportRepository interface {
+	WithTx(tx pgx.Tx) AirportRepository
+	Get(ctx context.Context, code string) (*model.Airport, error)
+	Upsert(ctx context.Context, airports []*model.Airport) (int, error)
+}
+
+// StatusRepository переходы рейсов между операционными статусами
+type StatusRepository interface {
+	WithTx(tx pgx.Tx) StatusRepository
+	Add(ctx context.Context, transition *model.FlightStatusTransition) error
+	List(ctx context.Context, flightNumber string, departureDate time.Time) ([]*model.FlightStatusTransition, error)
+}
+
+// OffsetRepository последний обработанный offset по партиции для транзакционного consumer
+type OffsetRepository interface {
+	WithTx(tx pgx.Tx) OffsetRepository
+	// MarkProcessed сохраняет offset и возвращает false, если он уже был обработан
+	MarkProcessed(ctx context.Context, offset model.KafkaOffset) (bool, error)
+	// SaveEvent и GetEvent хранят событие, отправленное в Kafka транзакции вместе с offset
+	SaveEvent(ctx context.Context, offset model.KafkaOffset, event *model.OutboxMessage) error
+	GetEvent(ctx context.Context, offset model.KafkaOffset) (*model.OutboxMessage, error)
+}
+
+// InboxRepository журнал обработанных сообщений consumer по meta ID
+type InboxRepository interface {
+	WithTx(tx pgx.Tx) InboxRepository
+	// Claim записывает meta ID в inbox и возвращает false, если сообщение уже обработано
+	Claim(ctx context.Context, metaID int) (bool, error)
+}
+
+// OutboxRepository исходящие события, записанные в транзакции обработки
+type OutboxRepository interface {
+	WithTx(tx pgx.Tx) OutboxRepository
+	Add(ctx context.Context, topic, key string, payload any) error
+	// TryLockRelay берет блокировку отправителя до конца транзакции, false - outbox отправляет другая реплика
+	TryLockRelay(ctx context.Context) (bool, error)
+	// FetchPending возвращает самые старые сообщения, вызывается внутри транзакции после TryLockRelay
+	FetchPending(ctx context.Context, limit int) ([]*model.OutboxMessage, error)
+	Delete(ctx context.Context, ids []int64) error
+}
+
+// WebhookRepository подписки на изменения рейсов и журнал их доставки.
+// owner ограничивает выборку подписками клиента, пустой owner - без ограничения.
+type WebhookRepository interface {
+	WithTx(tx pgx.Tx) WebhookRepository
+	Create(ctx context.Context, sub *model.WebhookSubscription) (int, error)
+	Get(ctx context.Context, id int, owner string) (*model.WebhookSubscription, error)
+	List(ctx context.Context, owner string) ([]*model.WebhookSubscription, error)
+	Update(ctx context.Context, sub *model.WebhookSubscription) error
+	Delete(ctx context.Context, id int, owner string) error
+
+	// EnqueueDeliveries создает доставки для всех включенных подписок, подходящих рейсу
+	EnqueueDeliveries(ctx context.Context, flightNumber, eventType string, payload any) (int, error)
+	// ClaimDeliveries выбирает доставки, срок которых наступил, и откладывает их на lease,
+	// чтобы другие реплики не отправили их одновременно
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, delivery *model.WebhookDelivery, responseStatus int) error
+	// MarkFailed фиксирует ошибку; nextAttempt == nil означает исчерпание попыток.
+	// Подписка отключается после disableAfter ошибок подряд, возвращает true, если это произошло.
+	MarkFailed(ctx context.Context, delivery *model.WebhookDelivery, responseStatus int, lastError string, nextAttempt *time.Time, disableAfter int) (bool, error)
+	ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*model.WebhookDelivery, error)
+}
+
+// StreamRepository журнал событий потока обновлений рейсов для SSE и возобновления по Last-Event-ID
+type StreamRepository interface {
+	WithTx(tx pgx.Tx) StreamRepository
+	// Append сохраняет событие и уведомляет реплики через NOTIFY в канал channel.
+	// ID выдаются в порядке коммита транзакций, вызывается последней записью транзакции.
+	Append(ctx context.Context, channel string, event *model.StreamEvent) (int64, error)
+	Get(ctx context.Context, id int64) (*model.StreamEvent, error)
+	ListAfter(ctx context.Context, afterID int64, filter model.StreamFilter, limit int) ([]*model.StreamEvent, error)
+	// LastID возвращает наибольший ID события в журнале, 0 если журнал пуст
+	LastID(ctx context.Context) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SpillRepository хранилище сообщений, которые не поместились в очередь Kafka producer
+type SpillRepository interface {
+	WithTx(tx pgx.Tx) SpillRepository
+	Save(ctx context.Context, metaID int, request *model.FlightRequest) error
+	// HasPending сообщает, есть ли отложенные сообщения, которые еще не вернулись в очередь
+	HasPending(ctx context.Context) (bool, error)
+	// TryLockDrain берет транзакционный advisory lock разгрузки; false, если его держит другая реплика
+	TryLockDrain(ctx context.Context) (bool, error)
+	// Claim удаляет и возвращает самые старые сообщения; удаление откатывается вместе с транзакцией
+	Claim(ctx context.Context, limit int) ([]*model.SpilledMessage, error)
+}
+
+type APIKeyRepository interface {
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
 }
@@ -0,0 +1,139 @@
+package spillRepo
+
+import (
+	"context"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"sort"
+)
+
+// Константы для таблицы kafka_spill
+const (
+	TableKafkaSpill = "kafka_spill"
+	ColumnID        = "id"
+	ColumnMetaID    = "meta_id"
+	ColumnPayload   = "payload"
+)
+
+// drainLockID ключ advisory lock разгрузки kafka_spill
+const drainLockID int64 = 0x7370696c6c
+
+type spillRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewSpillRepository(db *pgxpool.Pool) repository.SpillRepository {
+	return &spillRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *spillRepository) WithTx(tx pgx.Tx) repository.SpillRepository {
+	return &spillRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *spillRepository) Save(ctx context.Context, metaID int, request *model.FlightRequest) error {
+	ctx = repository.WithQueryName(ctx, TableKafkaSpill, "save")
+
+	query := r.sq.Insert(TableKafkaSpill).
+		Columns(ColumnMetaID, ColumnPayload).
+		Values(metaID, request).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return repository.WrapError(err)
+	}
+
+	return nil
+}
+
+// HasPending сообщает, есть ли в таблице неотправленные сообщения
+func (r *spillRepository) HasPending(ctx context.Context) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableKafkaSpill, "has_pending")
+
+	query := r.sq.Select("1").
+		From(TableKafkaSpill).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var pending bool
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&pending); err != nil {
+		return false, repository.WrapError(err)
+	}
+	return pending, nil
+}
+
+// TryLockDrain берет транзакционный advisory lock: таблицу разгружает одна реплика за раз,
+// иначе сообщения одного рейса из разных пачек могли бы попасть в Kafka не по порядку
+func (r *spillRepository) TryLockDrain(ctx context.Context) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableKafkaSpill, "try_lock_drain")
+
+	var locked bool
+	if err := r.db.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", drainLockID).Scan(&locked); err != nil {
+		return false, repository.WrapError(err)
+	}
+	return locked, nil
+}
+
+// Claim удаляет и возвращает самые старые сообщения в порядке записи.
+// Удаление фиксируется вместе с транзакцией, при откате сообщения остаются в таблице.
+func (r *spillRepository) Claim(ctx context.Context, limit int) ([]*model.SpilledMessage, error) {
+	ctx = repository.WithQueryName(ctx, TableKafkaSpill, "claim")
+
+	oldest := r.sq.Select(ColumnID).
+		From(TableKafkaSpill).
+		OrderBy(ColumnID).
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query := r.sq.Delete(TableKafkaSpill).
+		Where(oldest.Prefix(ColumnID + " IN (").Suffix(")")).
+		Suffix("RETURNING " + ColumnID + ", " + ColumnMetaID + ", " + ColumnPayload).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var messages []*model.SpilledMessage
+	for rows.Next() {
+		msg := &model.SpilledMessage{}
+		if err := rows.Scan(&msg.ID, &msg.MetaID, &msg.Request); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
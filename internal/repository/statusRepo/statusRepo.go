@@ -0,0 +1,101 @@
+package statusRepo
+
+import (
+	"context"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// Константы для таблицы flight_status_transitions
+const (
+	TableFlightStatusTransitions = "flight_status_transitions"
+	ColumnID                     = "id"
+	ColumnFlightNumber           = "flight_number"
+	ColumnDepartureDate          = "departure_date"
+	ColumnMetaID                 = "meta_id"
+	ColumnFromStatus             = "from_status"
+	ColumnToStatus               = "to_status"
+	ColumnOccurredAt             = "occurred_at"
+	ColumnRecordedAt             = "recorded_at"
+)
+
+type statusRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewStatusRepository(db *pgxpool.Pool) repository.StatusRepository {
+	return &statusRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *statusRepository) WithTx(tx pgx.Tx) repository.StatusRepository {
+	return &statusRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *statusRepository) Add(ctx context.Context, transition *model.FlightStatusTransition) error {
+	ctx = repository.WithQueryName(ctx, TableFlightStatusTransitions, "add")
+
+	query := r.sq.Insert(TableFlightStatusTransitions).
+		Columns(ColumnFlightNumber, ColumnDepartureDate, ColumnMetaID, ColumnFromStatus, ColumnToStatus, ColumnOccurredAt).
+		Values(transition.FlightNumber, transition.DepartureDate, transition.MetaID, transition.FromStatus, transition.ToStatus, transition.OccurredAt).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, sql, args...)
+	return repository.WrapError(err)
+}
+
+// List возвращает переходы рейса в порядке записи
+func (r *statusRepository) List(ctx context.Context, flightNumber string, departureDate time.Time) ([]*model.FlightStatusTransition, error) {
+	ctx = repository.WithQueryName(ctx, TableFlightStatusTransitions, "list")
+
+	query := r.sq.Select(ColumnID, ColumnMetaID, ColumnFromStatus, ColumnToStatus, ColumnOccurredAt, ColumnRecordedAt).
+		From(TableFlightStatusTransitions).
+		Where(squirrel.Eq{ColumnFlightNumber: flightNumber, ColumnDepartureDate: departureDate}).
+		OrderBy(ColumnID).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var transitions []*model.FlightStatusTransition
+	for rows.Next() {
+		transition := &model.FlightStatusTransition{
+			FlightNumber:  flightNumber,
+			DepartureDate: departureDate,
+		}
+		err = rows.Scan(&transition.ID, &transition.MetaID, &transition.FromStatus, &transition.ToStatus,
+			&transition.OccurredAt, &transition.RecordedAt)
+		if err != nil {
+			return nil, repository.WrapError(err)
+		}
+		transitions = append(transitions, transition)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, repository.WrapError(err)
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,216 @@
+package streamRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"strconv"
+	"time"
+)
+
+// Константы для таблицы flight_stream_events
+const (
+	TableStreamEvents   = "flight_stream_events"
+	ColumnID            = "id"
+	ColumnEventType     = "event_type"
+	ColumnFlightNumber  = "flight_number"
+	ColumnDepartureDate = "departure_date"
+	ColumnAircraftType  = "aircraft_type"
+	ColumnPayload       = "payload"
+	ColumnCreatedAt     = "created_at"
+)
+
+// appendLockID ключ advisory lock, под которым событиям выдаются ID
+const appendLockID int64 = 0x73747265616d
+
+var eventColumns = []string{ColumnID, ColumnEventType, ColumnFlightNumber, ColumnDepartureDate,
+	ColumnAircraftType, ColumnPayload, ColumnCreatedAt}
+
+type streamRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewStreamRepository(db *pgxpool.Pool) repository.StreamRepository {
+	return &streamRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *streamRepository) WithTx(tx pgx.Tx) repository.StreamRepository {
+	return &streamRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+// Append сохраняет событие и отправляет его ID в канал NOTIFY.
+// Внутри транзакции уведомление доставляется слушателям только после коммита.
+//
+// ID выдается под транзакционным advisory lock, который держится до коммита, поэтому
+// события становятся видны строго в порядке ID: когда видно событие N, все события
+// с меньшим ID уже закоммичены, и возобновление после N по Last-Event-ID ничего не теряет.
+// Append должен быть последней записью транзакции, чтобы блокировка держалась недолго.
+//
+// Блокировка общая для всех рейсов: при включенном потоке коммиты транзакций обработки
+// рейсов выполняются по одному, и пропускная способность записи ограничена временем
+// коммита (включая fsync WAL). См. config.StreamConfig.
+func (r *streamRepository) Append(ctx context.Context, channel string, event *model.StreamEvent) (int64, error) {
+	ctx = repository.WithQueryName(ctx, TableStreamEvents, "append")
+
+	if _, err := r.db.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", appendLockID); err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	query := r.sq.Insert(TableStreamEvents).
+		Columns(ColumnEventType, ColumnFlightNumber, ColumnDepartureDate, ColumnAircraftType, ColumnPayload).
+		Values(event.Type, event.FlightNumber, event.DepartureDate, event.AircraftType, event.Payload).
+		Suffix("RETURNING " + ColumnID).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	if _, err := r.db.Exec(ctx, "SELECT pg_notify($1, $2)", channel, strconv.FormatInt(id, 10)); err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	return id, nil
+}
+
+func (r *streamRepository) Get(ctx context.Context, id int64) (*model.StreamEvent, error) {
+	ctx = repository.WithQueryName(ctx, TableStreamEvents, "get")
+
+	query := r.sq.Select(eventColumns...).
+		From(TableStreamEvents).
+		Where(squirrel.Eq{ColumnID: id}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := scanEvent(r.db.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("stream_event_not_found", fmt.Sprintf("stream event %d not found", id))
+		}
+		return nil, repository.WrapError(err)
+	}
+
+	return event, nil
+}
+
+// ListAfter возвращает события с ID больше afterID, подходящие под фильтр, в порядке ID
+func (r *streamRepository) ListAfter(ctx context.Context, afterID int64, filter model.StreamFilter, limit int) ([]*model.StreamEvent, error) {
+	ctx = repository.WithQueryName(ctx, TableStreamEvents, "list_after")
+
+	conditions := squirrel.And{squirrel.Gt{ColumnID: afterID}}
+	if filter.FlightNumber != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnFlightNumber: filter.FlightNumber})
+	}
+	if filter.AircraftType != "" {
+		conditions = append(conditions, squirrel.Eq{ColumnAircraftType: filter.AircraftType})
+	}
+	if !filter.DepartureFrom.IsZero() {
+		conditions = append(conditions, squirrel.GtOrEq{ColumnDepartureDate: filter.DepartureFrom})
+	}
+	if !filter.DepartureTo.IsZero() {
+		conditions = append(conditions, squirrel.Lt{ColumnDepartureDate: filter.DepartureTo})
+	}
+
+	query := r.sq.Select(eventColumns...).
+		From(TableStreamEvents).
+		Where(conditions).
+		OrderBy(ColumnID).
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var events []*model.StreamEvent
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *streamRepository) LastID(ctx context.Context) (int64, error) {
+	ctx = repository.WithQueryName(ctx, TableStreamEvents, "last_id")
+
+	query := r.sq.Select("COALESCE(MAX(" + ColumnID + "), 0)").
+		From(TableStreamEvents).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	return id, nil
+}
+
+// DeleteBefore удаляет события, созданные раньше before, и возвращает их количество
+func (r *streamRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx = repository.WithQueryName(ctx, TableStreamEvents, "delete_before")
+
+	query := r.sq.Delete(TableStreamEvents).
+		Where(squirrel.Lt{ColumnCreatedAt: before}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanEvent(row pgx.Row) (*model.StreamEvent, error) {
+	event := &model.StreamEvent{}
+	err := row.Scan(&event.ID, &event.Type, &event.FlightNumber, &event.DepartureDate,
+		&event.AircraftType, &event.Payload, &event.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
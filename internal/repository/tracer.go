@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type queryNameKey struct{}
+
+type queryStartKey struct{}
+
+// QueryName описывает запрос для метрик: таблица и операция
+type QueryName struct {
+	Table     string
+	Operation string
+}
+
+type queryStart struct {
+	name  QueryName
+	sql   string
+	start time.Time
+}
+
+// WithQueryName прикрепляет к контексту имя запроса, по которому QueryTracer размечает метрики
+func WithQueryName(ctx context.Context, table, operation string) context.Context {
+	return context.WithValue(ctx, queryNameKey{}, QueryName{Table: table, Operation: operation})
+}
+
+// QueryTracer записывает длительность, количество строк и ошибки каждого запроса
+// и логирует запросы, выполнявшиеся дольше порога
+type QueryTracer struct {
+	slowThreshold atomic.Int64
+}
+
+func NewQueryTracer(slowThreshold time.Duration) *QueryTracer {
+	t := &QueryTracer{}
+	t.SetSlowThreshold(slowThreshold)
+	return t
+}
+
+// SetSlowThreshold меняет порог медленного запроса, 0 отключает логирование
+func (t *QueryTracer) SetSlowThreshold(threshold time.Duration) {
+	t.slowThreshold.Store(int64(threshold))
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name, ok := ctx.Value(queryNameKey{}).(QueryName)
+	if !ok {
+		name = QueryName{Table: "unknown", Operation: operationFromSQL(data.SQL)}
+	}
+
+	return context.WithValue(ctx, queryStartKey{}, queryStart{
+		name:  name,
+		sql:   data.SQL,
+		start: time.Now(),
+	})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	qs, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	duration := time.Since(qs.start)
+	metrics.DbQueryDuration.WithLabelValues(qs.name.Table, qs.name.Operation).Observe(duration.Seconds())
+
+	if data.Err != nil {
+		metrics.DbQueryErrors.WithLabelValues(qs.name.Table, qs.name.Operation).Inc()
+	} else {
+		metrics.DbQueryRows.WithLabelValues(qs.name.Table, qs.name.Operation).Observe(float64(data.CommandTag.RowsAffected()))
+	}
+
+	threshold := time.Duration(t.slowThreshold.Load())
+	if threshold > 0 && duration >= threshold {
+		logger.Warn("Slow database query",
+			zap.String("table", qs.name.Table),
+			zap.String("operation", qs.name.Operation),
+			zap.Duration("duration", duration),
+			zap.String("sql", qs.sql),
+			zap.Error(data.Err))
+	}
+}
+
+// operationFromSQL берет первое ключевое слово запроса в качестве операции
+func operationFromSQL(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	return strings.ToLower(fields[0])
+}
@@ -0,0 +1,404 @@
+package webhookRepo
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// Константы для таблиц webhook_subscriptions и webhook_deliveries
+const (
+	TableSubscriptions = "webhook_subscriptions"
+	TableDeliveries    = "webhook_deliveries"
+
+	ColumnID                  = "id"
+	ColumnOwner               = "owner"
+	ColumnURL                 = "url"
+	ColumnSecret              = "secret"
+	ColumnFlightNumber        = "flight_number"
+	ColumnFlightPattern       = "flight_pattern"
+	ColumnEnabled             = "enabled"
+	ColumnConsecutiveFailures = "consecutive_failures"
+	ColumnCreatedAt           = "created_at"
+	ColumnDisabledAt          = "disabled_at"
+
+	ColumnSubscriptionID = "subscription_id"
+	ColumnEventType      = "event_type"
+	ColumnPayload        = "payload"
+	ColumnStatus         = "status"
+	ColumnAttempts       = "attempts"
+	ColumnNextAttemptAt  = "next_attempt_at"
+	ColumnResponseStatus = "response_status"
+	ColumnLastError      = "last_error"
+	ColumnDeliveredAt    = "delivered_at"
+)
+
+var subscriptionColumns = []string{ColumnID, ColumnOwner, ColumnURL, ColumnSecret,
+	"COALESCE(" + ColumnFlightNumber + ", '')", "COALESCE(" + ColumnFlightPattern + ", '')",
+	ColumnEnabled, ColumnConsecutiveFailures, ColumnCreatedAt, ColumnDisabledAt}
+
+type webhookRepository struct {
+	db repository.QueryRunner
+	sq squirrel.StatementBuilderType
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) repository.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *webhookRepository) WithTx(tx pgx.Tx) repository.WebhookRepository {
+	return &webhookRepository{
+		db: tx,
+		sq: squirrel.StatementBuilder,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, sub *model.WebhookSubscription) (int, error) {
+	ctx = repository.WithQueryName(ctx, TableSubscriptions, "create")
+
+	query := r.sq.Insert(TableSubscriptions).
+		Columns(ColumnOwner, ColumnURL, ColumnSecret, ColumnFlightNumber, ColumnFlightPattern).
+		Values(sub.Owner, sub.URL, sub.Secret, nullable(sub.FlightNumber), nullable(sub.FlightPattern)).
+		Suffix("RETURNING " + ColumnID + ", " + ColumnCreatedAt).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&sub.ID, &sub.CreatedAt); err != nil {
+		return 0, repository.WrapError(err)
+	}
+	sub.Enabled = true
+
+	return sub.ID, nil
+}
+
+func (r *webhookRepository) Get(ctx context.Context, id int, owner string) (*model.WebhookSubscription, error) {
+	ctx = repository.WithQueryName(ctx, TableSubscriptions, "get")
+
+	query := ownedBy(r.sq.Select(subscriptionColumns...).
+		From(TableSubscriptions).
+		Where(squirrel.Eq{ColumnID: id}), owner).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := scanSubscription(r.db.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NotFound("webhook_not_found", fmt.Sprintf("webhook %d not found", id))
+		}
+		return nil, repository.WrapError(err)
+	}
+
+	return sub, nil
+}
+
+func (r *webhookRepository) List(ctx context.Context, owner string) ([]*model.WebhookSubscription, error) {
+	ctx = repository.WithQueryName(ctx, TableSubscriptions, "list")
+
+	query := ownedBy(r.sq.Select(subscriptionColumns...).
+		From(TableSubscriptions).
+		OrderBy(ColumnID), owner).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	subs := []*model.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// Update меняет адрес, фильтры, секрет и признак включения подписки
+func (r *webhookRepository) Update(ctx context.Context, sub *model.WebhookSubscription) error {
+	ctx = repository.WithQueryName(ctx, TableSubscriptions, "update")
+
+	query := r.sq.Update(TableSubscriptions).
+		Set(ColumnURL, sub.URL).
+		Set(ColumnSecret, sub.Secret).
+		Set(ColumnFlightNumber, nullable(sub.FlightNumber)).
+		Set(ColumnFlightPattern, nullable(sub.FlightPattern)).
+		Set(ColumnEnabled, sub.Enabled).
+		Set(ColumnConsecutiveFailures, sub.ConsecutiveFailures).
+		Set(ColumnDisabledAt, sub.DisabledAt).
+		Where(squirrel.Eq{ColumnID: sub.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, sql, args...)
+	return repository.WrapError(err)
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id int, owner string) error {
+	ctx = repository.WithQueryName(ctx, TableSubscriptions, "delete")
+
+	query := r.sq.Delete(TableSubscriptions).
+		Where(squirrel.Eq{ColumnID: id}).
+		PlaceholderFormat(squirrel.Dollar)
+	if owner != "" {
+		query = query.Where(squirrel.Eq{ColumnOwner: owner})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return repository.WrapError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.NotFound("webhook_not_found", fmt.Sprintf("webhook %d not found", id))
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries подбирает подписки по точному номеру рейса или шаблону, где * - любая подстрока
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, flightNumber, eventType string, payload any) (int, error) {
+	ctx = repository.WithQueryName(ctx, TableDeliveries, "enqueue")
+
+	matching := r.sq.Select(ColumnID).
+		Column("?::varchar", eventType).
+		Column("?::jsonb", payload).
+		From(TableSubscriptions).
+		Where(squirrel.Eq{ColumnEnabled: true}).
+		Where(squirrel.Or{squirrel.Eq{ColumnFlightNumber: nil}, squirrel.Eq{ColumnFlightNumber: flightNumber}}).
+		Where(squirrel.Or{
+			squirrel.Eq{ColumnFlightPattern: nil},
+			squirrel.Expr("? LIKE replace("+ColumnFlightPattern+", '*', '%')", flightNumber),
+		})
+
+	query := r.sq.Insert(TableDeliveries).
+		Columns(ColumnSubscriptionID, ColumnEventType, ColumnPayload).
+		Select(matching).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, repository.WrapError(err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *webhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	ctx = repository.WithQueryName(ctx, TableDeliveries, "claim")
+
+	due := r.sq.Select(ColumnID).
+		From(TableDeliveries).
+		Where(squirrel.Eq{ColumnStatus: "pending"}).
+		Where(squirrel.Expr(ColumnNextAttemptAt + " <= CURRENT_TIMESTAMP")).
+		OrderBy(ColumnID).
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	dueSQL, dueArgs, err := due.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.sq.Update(TableDeliveries+" d").
+		Set(ColumnNextAttemptAt, squirrel.Expr("CURRENT_TIMESTAMP + make_interval(secs => ?)", lease.Seconds())).
+		Set(ColumnAttempts, squirrel.Expr("d."+ColumnAttempts+" + 1")).
+		From(TableSubscriptions+" s").
+		Where("d."+ColumnSubscriptionID+" = s."+ColumnID).
+		Where("s."+ColumnEnabled). // доставки отключенной подписки ждут ее повторного включения
+		Where("d."+ColumnID+" IN ("+dueSQL+")", dueArgs...).
+		Suffix("RETURNING d." + ColumnID + ", d." + ColumnSubscriptionID + ", d." + ColumnEventType + ", d." + ColumnPayload +
+			", d." + ColumnAttempts + ", s." + ColumnURL + ", s." + ColumnSecret).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		d := &model.WebhookDelivery{Status: "pending"}
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *webhookRepository) MarkDelivered(ctx context.Context, delivery *model.WebhookDelivery, responseStatus int) error {
+	ctx = repository.WithQueryName(ctx, TableDeliveries, "mark_delivered")
+
+	query := r.sq.Update(TableDeliveries).
+		Set(ColumnStatus, "delivered").
+		Set(ColumnResponseStatus, responseStatus).
+		Set(ColumnLastError, nil).
+		Set(ColumnDeliveredAt, squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{ColumnID: delivery.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if err := r.exec(ctx, query); err != nil {
+		return err
+	}
+
+	reset := r.sq.Update(TableSubscriptions).
+		Set(ColumnConsecutiveFailures, 0).
+		Where(squirrel.Eq{ColumnID: delivery.SubscriptionID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	return r.exec(ctx, reset)
+}
+
+func (r *webhookRepository) MarkFailed(ctx context.Context, delivery *model.WebhookDelivery, responseStatus int, lastError string,
+	nextAttempt *time.Time, disableAfter int) (bool, error) {
+	ctx = repository.WithQueryName(ctx, TableDeliveries, "mark_failed")
+
+	query := r.sq.Update(TableDeliveries).
+		Set(ColumnResponseStatus, pgtype.Int4{Int32: int32(responseStatus), Valid: responseStatus != 0}).
+		Set(ColumnLastError, lastError).
+		Where(squirrel.Eq{ColumnID: delivery.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+	if nextAttempt != nil {
+		query = query.Set(ColumnNextAttemptAt, *nextAttempt)
+	} else {
+		query = query.Set(ColumnStatus, "failed")
+	}
+
+	if err := r.exec(ctx, query); err != nil {
+		return false, err
+	}
+
+	failures := r.sq.Update(TableSubscriptions).
+		Set(ColumnConsecutiveFailures, squirrel.Expr(ColumnConsecutiveFailures+" + 1")).
+		Set(ColumnEnabled, squirrel.Expr(ColumnEnabled+" AND "+ColumnConsecutiveFailures+" + 1 < ?", disableAfter)).
+		Set(ColumnDisabledAt, squirrel.Expr("CASE WHEN "+ColumnEnabled+" AND "+ColumnConsecutiveFailures+" + 1 >= ? THEN CURRENT_TIMESTAMP ELSE "+ColumnDisabledAt+" END", disableAfter)).
+		Where(squirrel.Eq{ColumnID: delivery.SubscriptionID}).
+		Suffix("RETURNING " + ColumnEnabled).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := failures.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var enabled bool
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&enabled); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil // подписку удалили во время отправки
+		}
+		return false, repository.WrapError(err)
+	}
+
+	return !enabled, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*model.WebhookDelivery, error) {
+	ctx = repository.WithQueryName(ctx, TableDeliveries, "list")
+
+	query := r.sq.Select(ColumnID, ColumnSubscriptionID, ColumnEventType, ColumnStatus, ColumnAttempts,
+		ColumnResponseStatus, "COALESCE("+ColumnLastError+", '')", ColumnCreatedAt, ColumnDeliveredAt).
+		From(TableDeliveries).
+		Where(squirrel.Eq{ColumnSubscriptionID: subscriptionID}).
+		OrderBy(ColumnID + " DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, repository.WrapError(err)
+	}
+	defer rows.Close()
+
+	deliveries := []*model.WebhookDelivery{}
+	for rows.Next() {
+		d := &model.WebhookDelivery{}
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &d.Status, &d.Attempts,
+			&d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *webhookRepository) exec(ctx context.Context, query squirrel.UpdateBuilder) error {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, sql, args...)
+	return repository.WrapError(err)
+}
+
+func ownedBy(query squirrel.SelectBuilder, owner string) squirrel.SelectBuilder {
+	if owner == "" {
+		return query
+	}
+	return query.Where(squirrel.Eq{ColumnOwner: owner})
+}
+
+func nullable(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func scanSubscription(row pgx.Row) (*model.WebhookSubscription, error) {
+	sub := &model.WebhookSubscription{}
+	err := row.Scan(&sub.ID, &sub.Owner, &sub.URL, &sub.Secret, &sub.FlightNumber, &sub.FlightPattern,
+		&sub.Enabled, &sub.ConsecutiveFailures, &sub.CreatedAt, &sub.DisabledAt)
+	return sub, err
+}
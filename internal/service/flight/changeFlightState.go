@@ -0,0 +1,43 @@
+package flight
+
+import (
+	"context"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"time"
+)
+
+func (f *flightService) CancelFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error) {
+	return f.changeFlightState(ctx, model.FlightOpCancel, flightNumber, departureDate)
+}
+
+func (f *flightService) DeleteFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error) {
+	return f.changeFlightState(ctx, model.FlightOpDelete, flightNumber, departureDate)
+}
+
+func (f *flightService) RestoreFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error) {
+	return f.changeFlightState(ctx, model.FlightOpRestore, flightNumber, departureDate)
+}
+
+// changeFlightState ставит операцию в очередь через meta и Kafka, как и запись рейса.
+// Применимость проверяется сразу, чтобы клиент получил 404/410 синхронно,
+// и повторно consumer-ом в транзакции обработки.
+func (f *flightService) changeFlightState(ctx context.Context, operation string, flightNumber string, departureDate time.Time) (int, error) {
+	if flightNumber == "" || departureDate.IsZero() {
+		return 0, domain.Validation("missing_required_fields", "flight_number and departure_date are required")
+	}
+
+	current, err := f.flightRepo.Get(ctx, flightNumber, departureDate)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := nextFlightState(operation, current, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return f.enqueue(ctx, &model.FlightRequest{
+		FlightNumber:  flightNumber,
+		DepartureDate: departureDate,
+		Operation:     operation,
+	})
+}
@@ -0,0 +1,156 @@
+package flight
+
+import (
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"slices"
+	"time"
+)
+
+// changedFields возвращает JSON-имена изменившихся полей рейса. Для нового рейса (old == nil)
+// изменившимися считаются все поля данных. Ключевые поля и updated_at не сравниваются.
+func changedFields(old, next *model.FlightData) []string {
+	if old == nil {
+		return []string{"aircraft_type", "arrival_date", "passengers_count",
+			"estimated_departure", "actual_departure", "estimated_arrival", "actual_arrival", "delay_codes",
+			"origin", "destination"}
+	}
+
+	var changed []string
+	if old.AircraftType != next.AircraftType {
+		changed = append(changed, "aircraft_type")
+	}
+	if !old.ArrivalDate.Equal(next.ArrivalDate) {
+		changed = append(changed, "arrival_date")
+	}
+	if old.PassengersCount != next.PassengersCount {
+		changed = append(changed, "passengers_count")
+	}
+	if !sameTime(old.CancelledAt, next.CancelledAt) {
+		changed = append(changed, "cancelled_at")
+	}
+	if !sameTime(old.DeletedAt, next.DeletedAt) {
+		changed = append(changed, "deleted_at")
+	}
+	if old.Status != next.Status {
+		changed = append(changed, "status")
+	}
+	if !sameTime(old.EstimatedDeparture, next.EstimatedDeparture) {
+		changed = append(changed, "estimated_departure")
+	}
+	if !sameTime(old.ActualDeparture, next.ActualDeparture) {
+		changed = append(changed, "actual_departure")
+	}
+	if !sameTime(old.EstimatedArrival, next.EstimatedArrival) {
+		changed = append(changed, "estimated_arrival")
+	}
+	if !sameTime(old.ActualArrival, next.ActualArrival) {
+		changed = append(changed, "actual_arrival")
+	}
+	if !slices.Equal(old.DelayCodes, next.DelayCodes) {
+		changed = append(changed, "delay_codes")
+	}
+	if old.Origin != next.Origin {
+		changed = append(changed, "origin")
+	}
+	if old.Destination != next.Destination {
+		changed = append(changed, "destination")
+	}
+	return changed
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// upsertedFlight данные рейса из запроса на запись. Отметки отмены и удаления
+// сохраняются: снять их можно только операцией restore. Операционный статус запись не меняет,
+// как и фактические времена, если запрос их не передает: сбросить их можно PATCH с полем в маске.
+func upsertedFlight(request *model.FlightRequest, current *model.FlightData, now time.Time) *model.FlightData {
+	flight := &model.FlightData{
+		AircraftType:    request.AircraftType,
+		FlightNumber:    request.FlightNumber,
+		DepartureDate:   request.DepartureDate,
+		ArrivalDate:     request.ArrivalDate,
+		PassengersCount: request.PassengersCount,
+		UpdatedAt:       now,
+		Status:          model.FlightStatusScheduled,
+
+		EstimatedDeparture: request.EstimatedDeparture,
+		ActualDeparture:    request.ActualDeparture,
+		EstimatedArrival:   request.EstimatedArrival,
+		ActualArrival:      request.ActualArrival,
+		DelayCodes:         request.DelayCodes,
+
+		Origin:      request.Origin,
+		Destination: request.Destination,
+	}
+	if current != nil {
+		flight.CancelledAt = current.CancelledAt
+		flight.DeletedAt = current.DeletedAt
+		flight.Status = current.Status
+		flight.StatusChangedAt = current.StatusChangedAt
+
+		if flight.ActualDeparture == nil && !slices.Contains(request.UpdateMask, model.FlightFieldActualDeparture) {
+			flight.ActualDeparture = current.ActualDeparture
+		}
+		if flight.ActualArrival == nil && !slices.Contains(request.UpdateMask, model.FlightFieldActualArrival) {
+			flight.ActualArrival = current.ActualArrival
+		}
+	}
+	return flight
+}
+
+// nextFlightState применяет к рейсу отмену, удаление или восстановление.
+// Повторная отмена или удаление ничего не меняет. Возвращает ошибку, если операцию применить нельзя.
+// Отмена - переход в статус cancelled, восстановление возвращает отмененный рейс в scheduled.
+func nextFlightState(operation string, current *model.FlightData, now time.Time) (*model.FlightData, error) {
+	if current == nil {
+		return nil, domain.NotFound("flight_not_found", "flight not found")
+	}
+
+	if operation == model.FlightOpCancel {
+		return transitionStatus(current, model.FlightStatusCancelled, now, now)
+	}
+
+	next := *current
+	next.UpdatedAt = now
+	switch operation {
+	case model.FlightOpDelete:
+		if next.DeletedAt == nil {
+			next.DeletedAt = &now
+		}
+	case model.FlightOpRestore:
+		next.CancelledAt = nil
+		next.DeletedAt = nil
+		if next.Status == model.FlightStatusCancelled {
+			next.Status = model.FlightStatusScheduled
+			next.StatusChangedAt = &now
+		}
+	}
+	return &next, nil
+}
+
+// eventType тип исходящего события; flight.processed - сообщение обработано без изменений
+func eventType(operation string, changed []string) string {
+	if len(changed) == 0 {
+		return "flight.processed"
+	}
+	switch operation {
+	case model.FlightOpCancel:
+		return "flight.cancelled"
+	case model.FlightOpDelete:
+		return "flight.deleted"
+	case model.FlightOpRestore:
+		return "flight.restored"
+	case model.FlightOpStatus:
+		if slices.Contains(changed, "cancelled_at") {
+			return "flight.cancelled"
+		}
+		return "flight.status_changed"
+	}
+	return "flight.updated"
+}
@@ -0,0 +1,66 @@
+package flight
+
+import (
+	"testing"
+	"time"
+
+	"flight-service/internal/model"
+)
+
+func TestUpsertedFlightKeepsActualTimes(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	departed := now.Add(-time.Hour)
+	arrived := now.Add(-10 * time.Minute)
+	reported := now.Add(-50 * time.Minute)
+
+	current := &model.FlightData{
+		FlightNumber:    "SU100",
+		DepartureDate:   now.Add(-2 * time.Hour),
+		Status:          model.FlightStatusArrived,
+		ActualDeparture: &departed,
+		ActualArrival:   &arrived,
+	}
+
+	tests := []struct {
+		name          string
+		request       *model.FlightRequest
+		wantDeparture *time.Time
+		wantArrival   *time.Time
+	}{
+		{
+			name:          "schedule resend keeps actual times",
+			request:       &model.FlightRequest{FlightNumber: "SU100", DepartureDate: current.DepartureDate},
+			wantDeparture: &departed,
+			wantArrival:   &arrived,
+		},
+		{
+			name:          "reported actual time overrides",
+			request:       &model.FlightRequest{FlightNumber: "SU100", DepartureDate: current.DepartureDate, ActualDeparture: &reported},
+			wantDeparture: &reported,
+			wantArrival:   &arrived,
+		},
+		{
+			name: "patch mask resets actual time",
+			request: &model.FlightRequest{FlightNumber: "SU100", DepartureDate: current.DepartureDate,
+				Operation: model.FlightOpPatch, UpdateMask: []string{model.FlightFieldActualArrival}},
+			wantDeparture: &departed,
+			wantArrival:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flight := upsertedFlight(tt.request, current, now)
+
+			if flight.Status != current.Status {
+				t.Errorf("Status = %q, want %q", flight.Status, current.Status)
+			}
+			if !sameTime(flight.ActualDeparture, tt.wantDeparture) {
+				t.Errorf("ActualDeparture = %v, want %v", flight.ActualDeparture, tt.wantDeparture)
+			}
+			if !sameTime(flight.ActualArrival, tt.wantArrival) {
+				t.Errorf("ActualArrival = %v, want %v", flight.ActualArrival, tt.wantArrival)
+			}
+		})
+	}
+}
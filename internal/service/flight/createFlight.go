@@ -2,6 +2,7 @@ package flight
 
 import (
 	"context"
+	"flight-service/internal/auth"
 	"flight-service/internal/logger"
 	"flight-service/internal/metrics"
 	"flight-service/internal/model"
@@ -10,18 +11,33 @@ import (
 )
 
 func (f *flightService) CreateFlight(ctx context.Context, request *model.FlightRequest) (int, error) {
+	if err := validateFlightRequest(request); err != nil {
+		return 0, err
+	}
+	if err := f.validateRoute(ctx, request.Origin, request.Destination); err != nil {
+		return 0, err
+	}
+
 	// Метрики по типу самолета
 	metrics.AircraftTypeCount.WithLabelValues(request.AircraftType).Inc()
 
 	// Метрики по пассажирам
 	metrics.Passengers.Observe(float64(request.PassengersCount))
 
+	request.Operation = model.FlightOpUpsert
+	return f.enqueue(ctx, request)
+}
+
+// enqueue создает запись meta со статусом pending и ставит запрос в очередь Kafka.
+// Результат обработки consumer-ом отражается в статусе meta.
+func (f *flightService) enqueue(ctx context.Context, request *model.FlightRequest) (int, error) {
 	// Создаем запись в таблице meta со статусом "pending"
 	meta := &model.FlightMeta{
 		FlightNumber:  request.FlightNumber,
 		DepartureDate: request.DepartureDate,
 		Status:        "pending",
 		CreatedAt:     time.Now(),
+		CreatedBy:     auth.CallerID(ctx),
 	}
 
 	id, err := f.metaRepo.Create(ctx, meta)
@@ -36,11 +52,22 @@ func (f *flightService) CreateFlight(ctx context.Context, request *model.FlightR
 	metrics.FlightMetaStatusCount.WithLabelValues("pending").Inc()
 
 	// Асинхронная отправка в Kafka через producer с каналом
-	err = f.kafkaProducer.SendFlightMessage(meta.ID, request)
+	err = f.kafkaProducer.SendFlightMessage(ctx, meta.ID, request)
 	if err != nil {
-		logger.Error("Failed to queue message for Kafka", zap.Error(err))
-		// Не возвращаем ошибку пользователю - сообщение в meta уже создано
-		// Можно обновить статус на "error" или оставить "pending" для повторной отправки
+		logger.Error("Failed to queue message for Kafka", zap.Int("metaID", meta.ID), zap.Error(err))
+
+		// Сообщение не будет отправлено: помечаем meta как error, чтобы запись не висела в pending.
+		// Контекст запроса может быть уже отменен, поэтому статус обновляем в отдельном.
+		updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if updateErr := f.metaRepo.UpdateStatus(updateCtx, meta.ID, "error"); updateErr != nil {
+			logger.Error("Failed to mark flight meta as error", zap.Int("metaID", meta.ID), zap.Error(updateErr))
+		} else {
+			metrics.FlightMetaStatusCount.WithLabelValues("pending").Dec()
+			metrics.FlightMetaStatusCount.WithLabelValues("error").Inc()
+		}
+
+		return 0, err
 	}
 
 	return meta.ID, nil
@@ -0,0 +1,57 @@
+package flight
+
+import (
+	"context"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"fmt"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ChangeFlightStatus ставит смену операционного статуса в очередь через meta и Kafka.
+// Сообщения одного рейса идут в одну партицию, поэтому consumer проверяет допустимость
+// переходов в порядке их отправки; результат виден по статусу и ошибке в meta.
+func (f *flightService) ChangeFlightStatus(ctx context.Context, request *model.FlightStatusRequest) (int, error) {
+	if request.FlightNumber == "" || request.DepartureDate.IsZero() || request.Status == "" {
+		return 0, domain.Validation("missing_required_fields", "flight_number, departure_date and status are required")
+	}
+	if !slices.Contains(model.FlightStatuses, request.Status) {
+		return 0, domain.Validation("invalid_status",
+			fmt.Sprintf("unknown status %q, allowed: %s", request.Status, strings.Join(model.FlightStatuses, ", ")))
+	}
+
+	occurredAt := request.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return f.enqueue(ctx, &model.FlightRequest{
+		FlightNumber:  request.FlightNumber,
+		DepartureDate: request.DepartureDate,
+		Operation:     model.FlightOpStatus,
+		Status:        request.Status,
+		StatusAt:      &occurredAt,
+	})
+}
+
+func (f *flightService) GetFlightStatus(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightStatusResponse, error) {
+	flight, err := f.GetFlight(ctx, flightNumber, departureDate)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := f.statusRepo.List(ctx, flightNumber, departureDate)
+	if err != nil {
+		logger.Error("Failed to get flight status transitions", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.FlightStatusResponse{
+		Flight:      flight,
+		Transitions: transitions,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Местная дата в любом поясе (от UTC-12 до UTC+14) попадает в это окно вокруг суток UTC
+const (
+	dayWindowBefore = 14 * time.Hour
+	dayWindowAfter  = 24*time.Hour + 12*time.Hour
+)
+
+// GetFlightsByLocalDate возвращает вылеты рейса, которые приходятся на дату date
+// по местному времени аэропорта вылета. Рейсы без аэропорта вылета считаются по UTC.
+func (f *flightService) GetFlightsByLocalDate(ctx context.Context, flightNumber string, date time.Time) (*model.FlightDayResponse, error) {
+	year, month, day := date.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	// Удаленные рейсы Search не возвращает
+	candidates, total, err := f.flightRepo.Search(ctx, model.FlightFilter{
+		FlightNumber:  flightNumber,
+		DepartureFrom: dayStart.Add(-dayWindowBefore),
+		DepartureTo:   dayStart.Add(dayWindowAfter),
+		Limit:         f.cfg.Current().Search.MaxLimit,
+	})
+	if err != nil {
+		logger.Error("Failed to search flights by local date", zap.Error(err))
+		return nil, err
+	}
+	if total > len(candidates) {
+		logger.Warn("Flight departures around the local date exceed search.max_limit, some are not returned",
+			zap.String("flightNumber", flightNumber),
+			zap.Time("date", dayStart),
+			zap.Int("total", total),
+			zap.Int("fetched", len(candidates)))
+	}
+
+	locations := make(map[string]*time.Location)
+	for _, flight := range candidates {
+		if _, ok := locations[flight.Origin]; ok {
+			continue
+		}
+		location, err := f.originLocation(ctx, flight.Origin)
+		if err != nil {
+			return nil, err
+		}
+		locations[flight.Origin] = location
+	}
+
+	flights := localDepartures(candidates, locations, dayStart)
+	if len(flights) == 0 {
+		return nil, domain.NotFound("flight_not_found",
+			fmt.Sprintf("flight %s has no departures on %s", flightNumber, dayStart.Format(time.DateOnly)))
+	}
+
+	return &model.FlightDayResponse{
+		FlightNumber: flightNumber,
+		Date:         dayStart,
+		Flights:      flights,
+	}, nil
+}
+
+// localDepartures отбирает рейсы, вылетающие в дату date по местному времени аэропорта вылета.
+// Часовой пояс берется из locations по коду аэропорта, при его отсутствии используется UTC.
+func localDepartures(candidates []*model.FlightData, locations map[string]*time.Location, date time.Time) []*model.LocalFlight {
+	year, month, day := date.Date()
+
+	flights := make([]*model.LocalFlight, 0, len(candidates))
+	for _, flight := range candidates {
+		location, ok := locations[flight.Origin]
+		if !ok {
+			location = time.UTC
+		}
+
+		// Перевод момента вылета в пояс аэропорта учитывает переходы на летнее время
+		local := flight.DepartureDate.In(location)
+		if y, m, d := local.Date(); y != year || m != month || d != day {
+			continue
+		}
+		flights = append(flights, &model.LocalFlight{FlightData: flight, DepartureLocal: local})
+	}
+	return flights
+}
+
+// originLocation часовой пояс аэропорта вылета; UTC, если аэропорт не указан или отсутствует в справочнике
+func (f *flightService) originLocation(ctx context.Context, code string) (*time.Location, error) {
+	if code == "" {
+		return time.UTC, nil
+	}
+
+	airport, err := f.airportRepo.Get(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) {
+		logger.Warn("Origin airport is not in the airports reference, using UTC", zap.String("origin", code))
+		return time.UTC, nil
+	}
+	if err != nil {
+		logger.Error("Failed to get origin airport", zap.String("origin", code), zap.Error(err))
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(airport.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %q of airport %s: %w", airport.Timezone, code, err)
+	}
+	return location, nil
+}
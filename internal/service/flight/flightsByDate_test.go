@@ -0,0 +1,198 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeSearchRepository отвечает на Search по списку рейсов с теми же границами, что и flightRepo
+type fakeSearchRepository struct {
+	repository.FlightRepository
+	flights []*model.FlightData
+}
+
+func (r *fakeSearchRepository) Search(_ context.Context, filter model.FlightFilter) ([]*model.FlightData, int, error) {
+	var found []*model.FlightData
+	for _, flight := range r.flights {
+		if flight.FlightNumber != filter.FlightNumber ||
+			flight.DepartureDate.Before(filter.DepartureFrom) || !flight.DepartureDate.Before(filter.DepartureTo) {
+			continue
+		}
+		found = append(found, flight)
+	}
+
+	total := len(found)
+	if len(found) > filter.Limit {
+		found = found[:filter.Limit]
+	}
+	return found, total, nil
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load timezone %s: %v", name, err)
+	}
+	return location
+}
+
+func TestLocalDepartures(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	kiritimati := mustLoadLocation(t, "Pacific/Kiritimati") // UTC+14
+	bakerIsland := mustLoadLocation(t, "Etc/GMT+12")        // UTC-12
+
+	locations := map[string]*time.Location{"BER": berlin, "CXI": kiritimati, "BAK": bakerIsland}
+
+	tests := []struct {
+		name      string
+		origin    string
+		departure time.Time
+		date      time.Time
+		want      bool
+	}{
+		// 25.10.2026 в Берлине переход с CEST (UTC+2) на CET (UTC+1)
+		{
+			name:      "late departure after DST change",
+			origin:    "BER",
+			departure: time.Date(2026, 10, 25, 22, 30, 0, 0, time.UTC), // 23:30 CET
+			date:      time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "late departure after DST change is not the next day",
+			origin:    "BER",
+			departure: time.Date(2026, 10, 25, 22, 30, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:      "early departure before DST change",
+			origin:    "BER",
+			departure: time.Date(2026, 10, 24, 22, 30, 0, 0, time.UTC), // 00:30 CEST
+			date:      time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "late departure on the day before DST change",
+			origin:    "BER",
+			departure: time.Date(2026, 10, 24, 21, 30, 0, 0, time.UTC), // 23:30 CEST
+			date:      time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:      "UTC+14 local midnight",
+			origin:    "CXI",
+			departure: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "UTC+14 previous local day",
+			origin:    "CXI",
+			departure: time.Date(2026, 10, 18, 9, 59, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:      "UTC-12 end of local day",
+			origin:    "BAK",
+			departure: time.Date(2026, 10, 20, 11, 59, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "UTC-12 next local day",
+			origin:    "BAK",
+			departure: time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:      "unknown origin uses UTC",
+			origin:    "XXX",
+			departure: time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "no origin uses UTC",
+			departure: time.Date(2026, 10, 20, 0, 30, 0, 0, time.UTC),
+			date:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flight := &model.FlightData{FlightNumber: "SU100", Origin: tt.origin, DepartureDate: tt.departure}
+			got := localDepartures([]*model.FlightData{flight}, locations, tt.date)
+			if (len(got) == 1) != tt.want {
+				t.Fatalf("localDepartures() = %d flights, want included %v", len(got), tt.want)
+			}
+			if tt.want && !got[0].DepartureLocal.Equal(tt.departure) {
+				t.Errorf("DepartureLocal = %v, want %v", got[0].DepartureLocal, tt.departure)
+			}
+		})
+	}
+}
+
+func TestGetFlightsByLocalDate(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	flights := []*model.FlightData{
+		// Самый ранний и самый поздний вылет даты, попадающие на границы окна поиска
+		{FlightNumber: "SU100", Origin: "CXI", DepartureDate: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)},
+		{FlightNumber: "SU100", Origin: "BAK", DepartureDate: time.Date(2026, 10, 20, 11, 59, 0, 0, time.UTC)},
+		// Аэропорта нет в справочнике, дата считается по UTC
+		{FlightNumber: "SU100", Origin: "XXX", DepartureDate: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)},
+		{FlightNumber: "SU100", Origin: "XXX", DepartureDate: time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC)},
+		{FlightNumber: "SU200", Origin: "CXI", DepartureDate: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+	}
+
+	repo := &fakeSearchRepository{flights: flights}
+	f := &flightService{
+		flightRepo: repo,
+		airportRepo: fakeAirportRepository{
+			"CXI": {Code: "CXI", Timezone: "Pacific/Kiritimati"},
+			"BAK": {Code: "BAK", Timezone: "Etc/GMT+12"},
+		},
+		cfg: config.NewStore(&config.Config{Search: config.SearchConfig{MaxLimit: 100}}),
+	}
+
+	response, err := f.GetFlightsByLocalDate(context.Background(), "SU100", date.Add(15*time.Hour))
+	if err != nil {
+		t.Fatalf("GetFlightsByLocalDate() error = %v", err)
+	}
+	if !response.Date.Equal(date) {
+		t.Errorf("Date = %v, want %v", response.Date, date)
+	}
+
+	want := []time.Time{flights[0].DepartureDate, flights[1].DepartureDate, flights[2].DepartureDate}
+	if len(response.Flights) != len(want) {
+		t.Fatalf("flights = %d, want %d", len(response.Flights), len(want))
+	}
+	for i, flight := range response.Flights {
+		if !flight.DepartureDate.Equal(want[i]) {
+			t.Errorf("flight %d departure = %v, want %v", i, flight.DepartureDate, want[i])
+		}
+	}
+	if got := response.Flights[2].DepartureLocal.Location(); got != time.UTC {
+		t.Errorf("unknown origin location = %v, want UTC", got)
+	}
+
+	if _, err := f.GetFlightsByLocalDate(context.Background(), "SU100", date.AddDate(0, 0, 5)); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetFlightsByLocalDate() on a date without departures error = %v, want not found", err)
+	}
+}
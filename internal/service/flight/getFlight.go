@@ -2,8 +2,11 @@ package flight
 
 import (
 	"context"
+	"errors"
+	"flight-service/internal/domain"
 	"flight-service/internal/logger"
 	"flight-service/internal/model"
+	"fmt"
 	"go.uber.org/zap"
 	"time"
 )
@@ -11,9 +14,31 @@ import (
 func (f *flightService) GetFlight(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error) {
 	flight, err := f.flightRepo.Get(ctx, flightNumber, departureDate)
 	if err != nil {
-		logger.Error("Failed to get flight", zap.Error(err))
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.Error("Failed to get flight", zap.Error(err))
+		}
 		return nil, err
 	}
+	if flight.DeletedAt != nil {
+		return nil, domain.Gone("flight_deleted",
+			fmt.Sprintf("flight with number %s and departure date %s was deleted", flightNumber, departureDate.Format(time.RFC3339)))
+	}
+
+	return flight, nil
+}
+
+func (f *flightService) GetFlightAsOf(ctx context.Context, flightNumber string, departureDate time.Time, asOf time.Time) (*model.FlightData, error) {
+	flight, err := f.historyRepo.GetAsOf(ctx, flightNumber, departureDate, asOf)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.Error("Failed to get flight as of", zap.Time("asOf", asOf), zap.Error(err))
+		}
+		return nil, err
+	}
+	if flight.DeletedAt != nil {
+		return nil, domain.Gone("flight_deleted",
+			fmt.Sprintf("flight with number %s and departure date %s was deleted", flightNumber, departureDate.Format(time.RFC3339)))
+	}
 
 	return flight, nil
 }
@@ -0,0 +1,32 @@
+package flight
+
+import (
+	"context"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (f *flightService) GetFlightHistory(ctx context.Context, flightNumber string, departureDate time.Time, limit int) (*model.FlightHistoryResponse, error) {
+	metaCfg := f.cfg.Current().Meta
+	if limit <= 0 || limit > metaCfg.MaxLimit {
+		limit = metaCfg.DefaultLimit
+	}
+
+	entries, total, err := f.historyRepo.List(ctx, flightNumber, departureDate, limit)
+	if err != nil {
+		logger.Error("Failed to get flight history", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.FlightHistoryResponse{
+		FlightNumber:  flightNumber,
+		DepartureDate: departureDate,
+		History:       entries,
+		Pagination: model.Pagination{
+			Total: total,
+			Limit: limit,
+		},
+	}, nil
+}
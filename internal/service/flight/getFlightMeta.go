@@ -8,8 +8,9 @@ import (
 )
 
 func (f *flightService) GetFlightMeta(ctx context.Context, flightNumber string, status string, limit int) (*model.FlightMetaResponse, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	metaCfg := f.cfg.Current().Meta
+	if limit <= 0 || limit > metaCfg.MaxLimit {
+		limit = metaCfg.DefaultLimit
 	}
 	offset := 0
 
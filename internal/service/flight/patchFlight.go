@@ -0,0 +1,171 @@
+package flight
+
+import (
+	"context"
+	"flight-service/internal/domain"
+	"flight-service/internal/metrics"
+	"flight-service/internal/model"
+	"slices"
+)
+
+// PatchFlight ставит в очередь частичное обновление рейса. Маска передается в сообщении Kafka,
+// consumer обновляет только перечисленные в ней колонки. Наличие рейса и согласованность
+// полей с сохраненными проверяются сразу, чтобы клиент получил ошибку синхронно,
+// и повторно consumer-ом в транзакции обработки по заблокированной строке.
+func (f *flightService) PatchFlight(ctx context.Context, patch *model.FlightPatchRequest) (int, error) {
+	if patch.FlightNumber == "" || patch.DepartureDate.IsZero() {
+		return 0, domain.Validation("missing_required_fields", "flight_number and departure_date are required")
+	}
+
+	mask := patch.UpdateMask
+	if len(mask) == 0 {
+		mask = presentFields(patch)
+	}
+	if err := validateUpdateMask(mask); err != nil {
+		return 0, err
+	}
+	current, err := f.flightRepo.Get(ctx, patch.FlightNumber, patch.DepartureDate)
+	if err != nil {
+		return 0, err
+	}
+
+	request := &model.FlightRequest{
+		FlightNumber:  patch.FlightNumber,
+		DepartureDate: patch.DepartureDate,
+		Operation:     model.FlightOpPatch,
+		UpdateMask:    mask,
+	}
+	// Поля вне маски игнорируются, поля из маски без значения сбрасываются
+	if slices.Contains(mask, model.FlightFieldAircraftType) && patch.AircraftType != nil {
+		request.AircraftType = *patch.AircraftType
+	}
+	if slices.Contains(mask, model.FlightFieldArrivalDate) && patch.ArrivalDate != nil {
+		request.ArrivalDate = *patch.ArrivalDate
+	}
+	if slices.Contains(mask, model.FlightFieldPassengersCount) && patch.PassengersCount != nil {
+		request.PassengersCount = *patch.PassengersCount
+	}
+	if slices.Contains(mask, model.FlightFieldEstimatedDeparture) {
+		request.EstimatedDeparture = patch.EstimatedDeparture
+	}
+	if slices.Contains(mask, model.FlightFieldActualDeparture) {
+		request.ActualDeparture = patch.ActualDeparture
+	}
+	if slices.Contains(mask, model.FlightFieldEstimatedArrival) {
+		request.EstimatedArrival = patch.EstimatedArrival
+	}
+	if slices.Contains(mask, model.FlightFieldActualArrival) {
+		request.ActualArrival = patch.ActualArrival
+	}
+	if slices.Contains(mask, model.FlightFieldDelayCodes) {
+		request.DelayCodes = patch.DelayCodes
+	}
+	if slices.Contains(mask, model.FlightFieldOrigin) && patch.Origin != nil {
+		request.Origin = *patch.Origin
+	}
+	if slices.Contains(mask, model.FlightFieldDestination) && patch.Destination != nil {
+		request.Destination = *patch.Destination
+	}
+
+	if err := validateFlightRequest(mergedPatch(request, current)); err != nil {
+		return 0, err
+	}
+	// По справочнику проверяются только коды из запроса, сохраненные уже проверены
+	if err := f.validateRoute(ctx, request.Origin, request.Destination); err != nil {
+		return 0, err
+	}
+
+	if slices.Contains(mask, model.FlightFieldAircraftType) {
+		metrics.AircraftTypeCount.WithLabelValues(request.AircraftType).Inc()
+	}
+	if slices.Contains(mask, model.FlightFieldPassengersCount) {
+		metrics.Passengers.Observe(float64(request.PassengersCount))
+	}
+
+	return f.enqueue(ctx, request)
+}
+
+// mergedPatch возвращает сохраненный рейс с примененным частичным обновлением:
+// поля из маски берутся из запроса, остальные из current
+func mergedPatch(request *model.FlightRequest, current *model.FlightData) *model.FlightRequest {
+	merged := &model.FlightRequest{
+		FlightNumber:    request.FlightNumber,
+		DepartureDate:   request.DepartureDate,
+		Operation:       request.Operation,
+		UpdateMask:      request.UpdateMask,
+		AircraftType:    current.AircraftType,
+		ArrivalDate:     current.ArrivalDate,
+		PassengersCount: current.PassengersCount,
+
+		EstimatedDeparture: current.EstimatedDeparture,
+		ActualDeparture:    current.ActualDeparture,
+		EstimatedArrival:   current.EstimatedArrival,
+		ActualArrival:      current.ActualArrival,
+		DelayCodes:         current.DelayCodes,
+
+		Origin:      current.Origin,
+		Destination: current.Destination,
+	}
+
+	for _, field := range request.UpdateMask {
+		switch field {
+		case model.FlightFieldAircraftType:
+			merged.AircraftType = request.AircraftType
+		case model.FlightFieldArrivalDate:
+			merged.ArrivalDate = request.ArrivalDate
+		case model.FlightFieldPassengersCount:
+			merged.PassengersCount = request.PassengersCount
+		case model.FlightFieldEstimatedDeparture:
+			merged.EstimatedDeparture = request.EstimatedDeparture
+		case model.FlightFieldActualDeparture:
+			merged.ActualDeparture = request.ActualDeparture
+		case model.FlightFieldEstimatedArrival:
+			merged.EstimatedArrival = request.EstimatedArrival
+		case model.FlightFieldActualArrival:
+			merged.ActualArrival = request.ActualArrival
+		case model.FlightFieldDelayCodes:
+			merged.DelayCodes = request.DelayCodes
+		case model.FlightFieldOrigin:
+			merged.Origin = request.Origin
+		case model.FlightFieldDestination:
+			merged.Destination = request.Destination
+		}
+	}
+	return merged
+}
+
+// presentFields маска из полей, переданных в теле запроса
+func presentFields(patch *model.FlightPatchRequest) []string {
+	var mask []string
+	if patch.AircraftType != nil {
+		mask = append(mask, model.FlightFieldAircraftType)
+	}
+	if patch.ArrivalDate != nil {
+		mask = append(mask, model.FlightFieldArrivalDate)
+	}
+	if patch.PassengersCount != nil {
+		mask = append(mask, model.FlightFieldPassengersCount)
+	}
+	if patch.EstimatedDeparture != nil {
+		mask = append(mask, model.FlightFieldEstimatedDeparture)
+	}
+	if patch.ActualDeparture != nil {
+		mask = append(mask, model.FlightFieldActualDeparture)
+	}
+	if patch.EstimatedArrival != nil {
+		mask = append(mask, model.FlightFieldEstimatedArrival)
+	}
+	if patch.ActualArrival != nil {
+		mask = append(mask, model.FlightFieldActualArrival)
+	}
+	if patch.DelayCodes != nil {
+		mask = append(mask, model.FlightFieldDelayCodes)
+	}
+	if patch.Origin != nil {
+		mask = append(mask, model.FlightFieldOrigin)
+	}
+	if patch.Destination != nil {
+		mask = append(mask, model.FlightFieldDestination)
+	}
+	return mask
+}
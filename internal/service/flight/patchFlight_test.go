@@ -0,0 +1,182 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+)
+
+// fakeGetRepository возвращает сохраненный рейс из Get
+type fakeGetRepository struct {
+	repository.FlightRepository
+	flight *model.FlightData
+}
+
+func (r *fakeGetRepository) Get(context.Context, string, time.Time) (*model.FlightData, error) {
+	if r.flight == nil {
+		return nil, domain.NotFound("flight_not_found", "flight not found")
+	}
+	return r.flight, nil
+}
+
+func TestPresentFields(t *testing.T) {
+	aircraft := "A321"
+	passengers := 0
+	origin := "SVO"
+	at := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		patch *model.FlightPatchRequest
+		want  []string
+	}{
+		{name: "empty", patch: &model.FlightPatchRequest{}, want: nil},
+		{
+			name:  "zero value is present",
+			patch: &model.FlightPatchRequest{PassengersCount: &passengers},
+			want:  []string{model.FlightFieldPassengersCount},
+		},
+		{
+			name: "several fields in mask order",
+			patch: &model.FlightPatchRequest{
+				Origin:        &origin,
+				ActualArrival: &at,
+				AircraftType:  &aircraft,
+				DelayCodes:    []string{},
+			},
+			want: []string{model.FlightFieldAircraftType, model.FlightFieldActualArrival,
+				model.FlightFieldDelayCodes, model.FlightFieldOrigin},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := presentFields(tt.patch); !slices.Equal(got, tt.want) {
+				t.Errorf("presentFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedPatch(t *testing.T) {
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	departed := departure.Add(5 * time.Minute)
+	estimated := departure.Add(3 * time.Hour)
+	arrived := departure.Add(3*time.Hour + 10*time.Minute)
+
+	current := &model.FlightData{
+		FlightNumber:       "SU100",
+		DepartureDate:      departure,
+		AircraftType:       "A320",
+		ArrivalDate:        departure.Add(3 * time.Hour),
+		PassengersCount:    150,
+		EstimatedArrival:   &estimated,
+		ActualDeparture:    &departed,
+		DelayCodes:         []string{"93"},
+		Origin:             "SVO",
+		Destination:        "LED",
+		EstimatedDeparture: &departed,
+	}
+	request := &model.FlightRequest{
+		FlightNumber:  "SU100",
+		DepartureDate: departure,
+		Operation:     model.FlightOpPatch,
+		UpdateMask:    []string{model.FlightFieldActualArrival, model.FlightFieldEstimatedArrival, model.FlightFieldPassengersCount},
+		// Поля вне маски в запросе пустые и не должны попасть в результат
+		ActualArrival: &arrived,
+	}
+
+	merged := mergedPatch(request, current)
+
+	want := &model.FlightRequest{
+		FlightNumber:       "SU100",
+		DepartureDate:      departure,
+		Operation:          model.FlightOpPatch,
+		UpdateMask:         request.UpdateMask,
+		AircraftType:       "A320",
+		ArrivalDate:        current.ArrivalDate,
+		PassengersCount:    0, // в маске, значение из запроса
+		EstimatedDeparture: &departed,
+		ActualDeparture:    &departed,
+		EstimatedArrival:   nil, // в маске без значения: сбрасывается
+		ActualArrival:      &arrived,
+		DelayCodes:         []string{"93"},
+		Origin:             "SVO",
+		Destination:        "LED",
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("mergedPatch() = %+v, want %+v", merged, want)
+	}
+	if current.ActualArrival != nil || current.PassengersCount != 150 {
+		t.Errorf("current flight was modified: %+v", current)
+	}
+}
+
+func TestPatchFlightValidatesMergedState(t *testing.T) {
+	departure := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	departed := departure.Add(30 * time.Minute)
+	early := departure.Add(10 * time.Minute)
+	origin := "SVO"
+
+	f := &flightService{
+		flightRepo: &fakeGetRepository{flight: &model.FlightData{
+			FlightNumber:    "SU100",
+			DepartureDate:   departure,
+			ActualDeparture: &departed,
+			Origin:          "SVO",
+			Destination:     "LED",
+		}},
+		airportRepo: fakeAirportRepository{"SVO": {Code: "SVO"}, "LED": {Code: "LED"}},
+	}
+
+	tests := []struct {
+		name     string
+		patch    *model.FlightPatchRequest
+		wantCode string
+	}{
+		{
+			name:     "actual arrival before stored actual departure",
+			patch:    &model.FlightPatchRequest{ActualArrival: &early},
+			wantCode: "invalid_actual_arrival",
+		},
+		{
+			name:     "destination equal to stored origin",
+			patch:    &model.FlightPatchRequest{Destination: &origin},
+			wantCode: "invalid_route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch.FlightNumber = "SU100"
+			tt.patch.DepartureDate = departure
+
+			_, err := f.PatchFlight(context.Background(), tt.patch)
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+				t.Fatalf("PatchFlight() error = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPatchFlightNotFound(t *testing.T) {
+	aircraft := "A321"
+	f := &flightService{flightRepo: &fakeGetRepository{}}
+
+	_, err := f.PatchFlight(context.Background(), &model.FlightPatchRequest{
+		FlightNumber:  "SU100",
+		DepartureDate: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		AircraftType:  &aircraft,
+	})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("PatchFlight() error = %v, want not found", err)
+	}
+}
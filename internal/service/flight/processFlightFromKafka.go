@@ -2,6 +2,10 @@ package flight
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/kafka"
 	"flight-service/internal/logger"
 	"flight-service/internal/metrics"
 	"flight-service/internal/model"
@@ -29,29 +33,241 @@ func (f *flightService) ProcessFlightFromKafka(ctx context.Context, metaID int,
 		}
 	}()
 
+	// В транзакционном режиме Kafka offset фиксируется в той же транзакции БД:
+	// если коммит Kafka транзакции не прошел, повторная доставка не запишет данные второй раз
+	if offset, ok := kafka.TransactionOffset(ctx); ok {
+		var fresh bool
+		fresh, err = f.offsetRepo.WithTx(tx).MarkProcessed(ctx, offset)
+		if err != nil {
+			return fmt.Errorf("failed to mark offset as processed: %w", err)
+		}
+		if !fresh {
+			// Kafka транзакция прошлой доставки могла откатиться уже после коммита БД,
+			// поэтому сохраненное событие отправляется в текущей транзакции заново
+			var event *model.OutboxMessage
+			event, err = f.offsetRepo.WithTx(tx).GetEvent(ctx, offset)
+			if err != nil {
+				return fmt.Errorf("failed to get processed offset event: %w", err)
+			}
+			if event != nil {
+				kafka.EmitInTransaction(ctx, event)
+			}
+
+			logger.Info("Kafka message already processed, skipping",
+				zap.Int("metaID", metaID),
+				zap.Int32("partition", offset.Partition),
+				zap.Int64("offset", offset.Offset))
+			return tx.Rollback(ctx)
+		}
+	}
+
+	// После ребалансировки обработанные, но не закоммиченные сообщения приходят повторно.
+	// Inbox пишется в той же транзакции, поэтому дубль подтверждается без побочных эффектов.
+	claimed, err := f.inboxRepo.WithTx(tx).Claim(ctx, metaID)
+	if err != nil {
+		return fmt.Errorf("failed to claim inbox entry: %w", err)
+	}
+	if !claimed {
+		metrics.KafkaDuplicateMessages.Inc()
+		logger.Info("Duplicate Kafka message, skipping",
+			zap.Int("metaID", metaID),
+			zap.String("flightNumber", request.FlightNumber))
+		return tx.Rollback(ctx)
+	}
+
 	// Создаем репозитории с транзакцией
 	metaRepoWithTx := f.metaRepo.WithTx(tx)
 	flightRepoWithTx := f.flightRepo.WithTx(tx)
 
+	// Прежнее состояние нужно для проверки операции, истории и события. Строка блокируется
+	// до коммита, чтобы параллельная обработка того же рейса не работала со старым состоянием.
+	// Строки нового рейса еще нет: параллельные вставки сериализует ON CONFLICT в upsert,
+	// а сообщения одного рейса и так обрабатываются по порядку в своей партиции.
+	var old *model.FlightData
+	old, err = flightRepoWithTx.GetForUpdate(ctx, request.FlightNumber, request.DepartureDate)
+	if errors.Is(err, domain.ErrNotFound) {
+		old, err = nil, nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get current flight: %w", err)
+	}
+
+	// 2. Применяем операцию к рейсу
+	operation := request.Operation
+	if operation == "" {
+		operation = model.FlightOpUpsert
+	}
+
+	var flightData *model.FlightData
+	var rejectErr error
+	switch operation {
+	case model.FlightOpUpsert:
+		flightData = upsertedFlight(request, old, time.Now())
+		err = flightRepoWithTx.Upsert(ctx, flightData)
+	case model.FlightOpPatch:
+		rejectErr = validateUpdateMask(request.UpdateMask)
+		if rejectErr == nil && old == nil {
+			// Частичное обновление не создает рейс: поля вне маски остались бы пустыми
+			rejectErr = domain.NotFound("flight_not_found", "flight not found")
+		}
+		var merged *model.FlightRequest
+		if rejectErr == nil {
+			// Поля из маски проверяются вместе с сохраненными, например actual_arrival с actual_departure
+			merged = mergedPatch(request, old)
+			rejectErr = validateFlightRequest(merged)
+		}
+		if rejectErr == nil {
+			flightData, err = flightRepoWithTx.Patch(ctx, upsertedFlight(merged, old, time.Now()), request.UpdateMask)
+		}
+	case model.FlightOpCancel, model.FlightOpDelete, model.FlightOpRestore:
+		flightData, rejectErr = nextFlightState(operation, old, time.Now())
+		if rejectErr == nil {
+			err = flightRepoWithTx.UpdateState(ctx, flightData)
+		}
+	case model.FlightOpStatus:
+		now := time.Now()
+		statusAt := now
+		if request.StatusAt != nil {
+			statusAt = *request.StatusAt
+		}
+		flightData, rejectErr = transitionStatus(old, request.Status, statusAt, now)
+		if rejectErr == nil {
+			err = flightRepoWithTx.UpdateState(ctx, flightData)
+		}
+	default:
+		rejectErr = domain.Validation("unknown_operation", fmt.Sprintf("unknown flight operation %q", operation))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s to flight: %w", operation, err)
+	}
+
+	// Операцию нельзя применить (рейса нет, он удален, маска некорректна или переход статуса
+	// недопустим), повтор не поможет: сообщение подтверждается, а причина записывается в meta
+	if rejectErr != nil {
+		err = metaRepoWithTx.Reject(ctx, metaID, rejectReason(rejectErr))
+		if err != nil {
+			return fmt.Errorf("failed to update meta status: %w", err)
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+
+		metrics.FlightMetaStatusCount.WithLabelValues("pending").Dec()
+		metrics.FlightMetaStatusCount.WithLabelValues("error").Inc()
+
+		logger.Warn("Flight operation rejected",
+			zap.Int("metaID", metaID),
+			zap.String("flightNumber", request.FlightNumber),
+			zap.String("operation", operation),
+			zap.Error(rejectErr))
+		return nil
+	}
+
 	err = metaRepoWithTx.UpdateStatus(ctx, metaID, "processed")
 	if err != nil {
 		return fmt.Errorf("failed to update meta status: %w", err)
 	}
 
-	// 2. Создаем или обновляем данные о полете
-	// Создаем FlightData из FlightRequest
-	flightData := &model.FlightData{
-		AircraftType:    request.AircraftType,
-		FlightNumber:    request.FlightNumber,
-		DepartureDate:   request.DepartureDate,
-		ArrivalDate:     request.ArrivalDate,
-		PassengersCount: request.PassengersCount,
-		UpdatedAt:       time.Now(),
+	changed := changedFields(old, flightData)
+
+	// Переход статуса хранится отдельно со временем события: по нему видна вся цепочка статусов
+	statusChanged := old != nil && old.Status != flightData.Status
+	if statusChanged {
+		err = f.statusRepo.WithTx(tx).Add(ctx, &model.FlightStatusTransition{
+			FlightNumber:  flightData.FlightNumber,
+			DepartureDate: flightData.DepartureDate,
+			MetaID:        metaID,
+			FromStatus:    old.Status,
+			ToStatus:      flightData.Status,
+			OccurredAt:    *flightData.StatusChangedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write status transition: %w", err)
+		}
 	}
 
-	err = flightRepoWithTx.Upsert(ctx, flightData)
+	// История пишется на каждую операцию, даже без изменений: по ней видно, какое сообщение подтвердило данные
+	err = f.historyRepo.WithTx(tx).Add(ctx, &model.FlightHistoryEntry{
+		FlightNumber:  flightData.FlightNumber,
+		DepartureDate: flightData.DepartureDate,
+		MetaID:        metaID,
+		Source:        model.HistorySourceKafka,
+		OldValues:     model.NewFlightSnapshot(old),
+		NewValues:     *model.NewFlightSnapshot(flightData),
+		ChangedFields: changed,
+		ChangedAt:     flightData.UpdatedAt,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to upsert flight: %w", err)
+		return fmt.Errorf("failed to write flight history: %w", err)
+	}
+
+	event := model.FlightUpdatedEvent{
+		Type:          eventType(operation, changed),
+		MetaID:        metaID,
+		FlightNumber:  flightData.FlightNumber,
+		DepartureDate: flightData.DepartureDate,
+		New:           model.NewFlightResponse(flightData),
+		ChangedFields: changed,
+		OccurredAt:    flightData.UpdatedAt,
+	}
+	if old != nil {
+		oldResponse := model.NewFlightResponse(old)
+		event.Old = &oldResponse
+	}
+
+	// Событие пишется в outbox в той же транзакции и отправляется OutboxRelay после коммита.
+	// В транзакционном режиме оно уходит в Kafka транзакции consumer вместе с offset,
+	// а в processed_offsets сохраняется для повторной отправки.
+	cfg := f.cfg.Current()
+	var txnEvent *model.OutboxMessage
+	if cfg.Kafka.Events.Enabled && len(changed) > 0 {
+		key := model.FlightKey(flightData.FlightNumber, flightData.DepartureDate)
+		if offset, ok := kafka.TransactionOffset(ctx); ok {
+			var payload []byte
+			payload, err = json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal flight.updated event: %w", err)
+			}
+			txnEvent = &model.OutboxMessage{Topic: cfg.Kafka.Events.Topic, Key: key, Payload: payload}
+			err = f.offsetRepo.WithTx(tx).SaveEvent(ctx, offset, txnEvent)
+			if err != nil {
+				return fmt.Errorf("failed to save flight.updated event: %w", err)
+			}
+		} else {
+			err = f.outboxRepo.WithTx(tx).Add(ctx, cfg.Kafka.Events.Topic, key, event)
+			if err != nil {
+				return fmt.Errorf("failed to write flight.updated event to outbox: %w", err)
+			}
+		}
+	}
+
+	// Доставки вебхуков тоже создаются в транзакции, Dispatcher отправит их после коммита
+	if cfg.Webhooks.Enabled {
+		_, err = f.webhookRepo.WithTx(tx).EnqueueDeliveries(ctx, flightData.FlightNumber, event.Type, event)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+		}
+	}
+
+	// Событие SSE потока: NOTIFY из этой транзакции дойдет до реплик только после коммита.
+	// Append берет блокировку до коммита, поэтому он остается последней записью транзакции.
+	if cfg.Stream.Enabled {
+		var payload []byte
+		payload, err = json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal stream event: %w", err)
+		}
+		_, err = f.streamRepo.WithTx(tx).Append(ctx, cfg.Stream.Channel, &model.StreamEvent{
+			Type:          event.Type,
+			FlightNumber:  flightData.FlightNumber,
+			DepartureDate: flightData.DepartureDate,
+			AircraftType:  flightData.AircraftType,
+			Payload:       payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to append stream event: %w", err)
+		}
 	}
 
 	// 3. Если все успешно - коммитим транзакцию
@@ -60,12 +276,25 @@ func (f *flightService) ProcessFlightFromKafka(ctx context.Context, metaID int,
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	// Отправка после коммита БД: неудачная попытка обработки не оставит в Kafka транзакции лишнего события
+	if txnEvent != nil {
+		kafka.EmitInTransaction(ctx, txnEvent)
+	}
+
 	metrics.FlightMetaStatusCount.WithLabelValues("pending").Dec()
 	metrics.FlightMetaStatusCount.WithLabelValues("processed").Inc()
 	metrics.FlightsProcessed.Inc()
+	if statusChanged {
+		metrics.FlightStatusTransitions.WithLabelValues(old.Status, flightData.Status).Inc()
+	}
+	// Задержка учитывается один раз, когда фактическое время вылета становится известно
+	if flightData.ActualDeparture != nil && (old == nil || old.ActualDeparture == nil) {
+		metrics.DepartureDelay.WithLabelValues(flightData.AircraftType).Observe(float64(*flightData.DepartureDelayMinutes()))
+	}
 
 	logger.Info("Successfully processed Kafka message",
 		zap.Int("metaID", metaID),
+		zap.String("operation", operation),
 		zap.String("flightNumber", request.FlightNumber))
 
 	return nil
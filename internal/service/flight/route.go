@@ -0,0 +1,46 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"fmt"
+	"regexp"
+)
+
+// airportCodePattern IATA код аэропорта
+var airportCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// validateAirportCode проверяет формат кода; пустой код допустим
+func validateAirportCode(field, code string) error {
+	if code != "" && !airportCodePattern.MatchString(code) {
+		return domain.Validation("invalid_airport_code",
+			fmt.Sprintf("%s must be a three-letter uppercase IATA code", field))
+	}
+	return nil
+}
+
+// validateRoute проверяет коды маршрута по справочнику airports.
+// Пустой код означает, что аэропорт не указан. Совпадение аэропортов проверяет validateFlightRequest.
+func (f *flightService) validateRoute(ctx context.Context, origin, destination string) error {
+	if err := f.validateAirport(ctx, model.FlightFieldOrigin, origin); err != nil {
+		return err
+	}
+	return f.validateAirport(ctx, model.FlightFieldDestination, destination)
+}
+
+func (f *flightService) validateAirport(ctx context.Context, field, code string) error {
+	if code == "" {
+		return nil
+	}
+	if err := validateAirportCode(field, code); err != nil {
+		return err
+	}
+
+	_, err := f.airportRepo.Get(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Validation("unknown_airport", fmt.Sprintf("%s %s is not in the airports reference", field, code))
+	}
+	return err
+}
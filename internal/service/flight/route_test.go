@@ -0,0 +1,67 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeAirportRepository справочник аэропортов в памяти
+type fakeAirportRepository map[string]*model.Airport
+
+func (r fakeAirportRepository) WithTx(pgx.Tx) repository.AirportRepository { return r }
+
+func (r fakeAirportRepository) Get(_ context.Context, code string) (*model.Airport, error) {
+	airport, ok := r[code]
+	if !ok {
+		return nil, domain.NotFound("airport_not_found", "airport "+code+" not found")
+	}
+	return airport, nil
+}
+
+func (r fakeAirportRepository) Upsert(context.Context, []*model.Airport) (int, error) { return 0, nil }
+
+func TestValidateRoute(t *testing.T) {
+	f := &flightService{airportRepo: fakeAirportRepository{
+		"SVO": {Code: "SVO", Timezone: "Europe/Moscow"},
+		"LED": {Code: "LED", Timezone: "Europe/Moscow"},
+	}}
+
+	tests := []struct {
+		name        string
+		origin      string
+		destination string
+		wantCode    string
+	}{
+		{name: "known route", origin: "SVO", destination: "LED"},
+		{name: "route not set", origin: "", destination: ""},
+		{name: "only origin", origin: "SVO", destination: ""},
+		{name: "lowercase code", origin: "svo", destination: "LED", wantCode: "invalid_airport_code"},
+		{name: "too long code", origin: "SVO", destination: "LEDD", wantCode: "invalid_airport_code"},
+		{name: "unknown origin", origin: "JFK", destination: "LED", wantCode: "unknown_airport"},
+		{name: "unknown destination", origin: "SVO", destination: "JFK", wantCode: "unknown_airport"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := f.validateRoute(context.Background(), tt.origin, tt.destination)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("validateRoute() error = %v", err)
+				}
+				return
+			}
+
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrValidation) || domainErr.Code != tt.wantCode {
+				t.Fatalf("validateRoute() error = %v, want validation error %s", err, tt.wantCode)
+			}
+		})
+	}
+}
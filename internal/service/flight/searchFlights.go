@@ -0,0 +1,42 @@
+package flight
+
+import (
+	"context"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"go.uber.org/zap"
+)
+
+func (f *flightService) SearchFlights(ctx context.Context, filter model.FlightFilter) (*model.FlightSearchResponse, error) {
+	searchCfg := f.cfg.Current().Search
+	if filter.Limit <= 0 || filter.Limit > searchCfg.MaxLimit {
+		filter.Limit = searchCfg.DefaultLimit
+	}
+	if filter.Offset < 0 {
+		return nil, domain.Validation("invalid_offset", "offset must not be negative")
+	}
+	if !filter.DepartureFrom.IsZero() && !filter.DepartureTo.IsZero() && filter.DepartureTo.Before(filter.DepartureFrom) {
+		return nil, domain.Validation("invalid_departure_range", "departure_to must not be before departure_from")
+	}
+	if err := validateAirportCode(model.FlightFieldOrigin, filter.Origin); err != nil {
+		return nil, err
+	}
+	if err := validateAirportCode(model.FlightFieldDestination, filter.Destination); err != nil {
+		return nil, err
+	}
+
+	flights, total, err := f.flightRepo.Search(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to search flights", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.FlightSearchResponse{
+		Flights: flights,
+		Pagination: model.Pagination{
+			Total: total,
+			Limit: filter.Limit,
+		},
+	}, nil
+}
@@ -1,6 +1,7 @@
 package flight
 
 import (
+	"flight-service/internal/config"
 	"flight-service/internal/kafka"
 	"flight-service/internal/repository"
 	"flight-service/internal/service"
@@ -10,17 +11,39 @@ import (
 type flightService struct {
 	metaRepo      repository.MetaRepository
 	flightRepo    repository.FlightRepository
+	historyRepo   repository.HistoryRepository
+	statusRepo    repository.StatusRepository
+	airportRepo   repository.AirportRepository
+	offsetRepo    repository.OffsetRepository
+	inboxRepo     repository.InboxRepository
+	outboxRepo    repository.OutboxRepository
+	webhookRepo   repository.WebhookRepository
+	streamRepo    repository.StreamRepository
 	kafkaProducer *kafka.Producer
 	dbPool        *pgxpool.Pool
+	cfg           *config.Store
 }
 
 // NewFlightService создает новый экземпляр FlightService
-func NewFlightService(metaRepo repository.MetaRepository, flightRepo repository.FlightRepository, kafkaProducer *kafka.Producer, dbPool *pgxpool.Pool) service.FlightService {
+func NewFlightService(metaRepo repository.MetaRepository, flightRepo repository.FlightRepository,
+	historyRepo repository.HistoryRepository, statusRepo repository.StatusRepository, airportRepo repository.AirportRepository,
+	offsetRepo repository.OffsetRepository,
+	inboxRepo repository.InboxRepository, outboxRepo repository.OutboxRepository,
+	webhookRepo repository.WebhookRepository, streamRepo repository.StreamRepository, kafkaProducer *kafka.Producer, dbPool *pgxpool.Pool, cfg *config.Store) service.FlightService {
 	fs := &flightService{
 		metaRepo:      metaRepo,
 		flightRepo:    flightRepo,
+		historyRepo:   historyRepo,
+		statusRepo:    statusRepo,
+		airportRepo:   airportRepo,
+		offsetRepo:    offsetRepo,
+		inboxRepo:     inboxRepo,
+		outboxRepo:    outboxRepo,
+		webhookRepo:   webhookRepo,
+		streamRepo:    streamRepo,
 		kafkaProducer: kafkaProducer,
 		dbPool:        dbPool,
+		cfg:           cfg,
 	}
 
 	return fs
@@ -0,0 +1,69 @@
+package flight
+
+import (
+	"errors"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// flightStatusTransitions допустимые переходы операционного статуса.
+// arrived и cancelled конечные: из cancelled рейс возвращается только операцией restore.
+var flightStatusTransitions = map[string][]string{
+	model.FlightStatusScheduled: {model.FlightStatusBoarding, model.FlightStatusCancelled},
+	model.FlightStatusBoarding:  {model.FlightStatusScheduled, model.FlightStatusDeparted, model.FlightStatusCancelled},
+	model.FlightStatusDeparted:  {model.FlightStatusAirborne},
+	model.FlightStatusAirborne:  {model.FlightStatusLanded, model.FlightStatusDiverted},
+	model.FlightStatusDiverted:  {model.FlightStatusLanded},
+	model.FlightStatusLanded:    {model.FlightStatusArrived},
+}
+
+// transitionStatus переводит рейс в статус status в момент at. Повтор текущего статуса ничего не меняет,
+// недопустимый переход возвращает Conflict. Перевод в cancelled отмечает рейс отмененным,
+// departed и arrived (уход от перрона и заруливание) задают фактическое время вылета и прилета.
+func transitionStatus(current *model.FlightData, status string, at time.Time, now time.Time) (*model.FlightData, error) {
+	if current == nil {
+		return nil, domain.NotFound("flight_not_found", "flight not found")
+	}
+	if current.DeletedAt != nil {
+		return nil, domain.Gone("flight_deleted", "deleted flight cannot change status, restore it first")
+	}
+
+	next := *current
+	next.UpdatedAt = now
+	if current.Status == status {
+		return &next, nil
+	}
+	if !slices.Contains(flightStatusTransitions[current.Status], status) {
+		return nil, domain.Conflict("illegal_status_transition",
+			fmt.Sprintf("flight status cannot change from %s to %s", current.Status, status), nil)
+	}
+
+	next.Status = status
+	next.StatusChangedAt = &at
+	switch status {
+	case model.FlightStatusCancelled:
+		next.CancelledAt = &at
+	case model.FlightStatusDeparted:
+		// Фактическое время, переданное источником явно, не перетирается
+		if next.ActualDeparture == nil {
+			next.ActualDeparture = &at
+		}
+	case model.FlightStatusArrived:
+		if next.ActualArrival == nil {
+			next.ActualArrival = &at
+		}
+	}
+	return &next, nil
+}
+
+// rejectReason текст причины отклонения для записи в meta
+func rejectReason(err error) string {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code + ": " + domainErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,201 @@
+package flight
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+)
+
+func TestFlightStatusTransitions(t *testing.T) {
+	allowed := map[[2]string]bool{
+		{model.FlightStatusScheduled, model.FlightStatusBoarding}:  true,
+		{model.FlightStatusScheduled, model.FlightStatusCancelled}: true,
+		{model.FlightStatusBoarding, model.FlightStatusScheduled}:  true,
+		{model.FlightStatusBoarding, model.FlightStatusDeparted}:   true,
+		{model.FlightStatusBoarding, model.FlightStatusCancelled}:  true,
+		{model.FlightStatusDeparted, model.FlightStatusAirborne}:   true,
+		{model.FlightStatusAirborne, model.FlightStatusLanded}:     true,
+		{model.FlightStatusAirborne, model.FlightStatusDiverted}:   true,
+		{model.FlightStatusDiverted, model.FlightStatusLanded}:     true,
+		{model.FlightStatusLanded, model.FlightStatusArrived}:      true,
+	}
+
+	for from, targets := range flightStatusTransitions {
+		if !slices.Contains(model.FlightStatuses, from) {
+			t.Errorf("unknown status %q in transitions", from)
+		}
+		for _, to := range targets {
+			if !slices.Contains(model.FlightStatuses, to) {
+				t.Errorf("unknown status %q in transitions from %q", to, from)
+			}
+		}
+	}
+
+	for _, from := range model.FlightStatuses {
+		for _, to := range model.FlightStatuses {
+			if from == to {
+				continue
+			}
+			got := slices.Contains(flightStatusTransitions[from], to)
+			if want := allowed[[2]string{from, to}]; got != want {
+				t.Errorf("transition %s -> %s allowed = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTransitionStatus(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := now.Add(-5 * time.Minute)
+	reported := now.Add(-10 * time.Minute)
+
+	flight := func(status string) *model.FlightData {
+		return &model.FlightData{FlightNumber: "SU100", DepartureDate: now, Status: status}
+	}
+
+	tests := []struct {
+		name    string
+		current *model.FlightData
+		status  string
+		wantErr error
+		check   func(t *testing.T, next *model.FlightData)
+	}{
+		{
+			name:    "missing flight",
+			status:  model.FlightStatusBoarding,
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "deleted flight",
+			current: func() *model.FlightData {
+				f := flight(model.FlightStatusScheduled)
+				f.DeletedAt = &at
+				return f
+			}(),
+			status:  model.FlightStatusBoarding,
+			wantErr: domain.ErrGone,
+		},
+		{
+			name:    "illegal transition",
+			current: flight(model.FlightStatusScheduled),
+			status:  model.FlightStatusAirborne,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name:    "arrived is terminal",
+			current: flight(model.FlightStatusArrived),
+			status:  model.FlightStatusLanded,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name:    "cancelled is terminal",
+			current: flight(model.FlightStatusCancelled),
+			status:  model.FlightStatusBoarding,
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name:    "same status is a no-op",
+			current: flight(model.FlightStatusBoarding),
+			status:  model.FlightStatusBoarding,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.StatusChangedAt != nil {
+					t.Errorf("StatusChangedAt = %v, want nil", next.StatusChangedAt)
+				}
+			},
+		},
+		{
+			name:    "boarding",
+			current: flight(model.FlightStatusScheduled),
+			status:  model.FlightStatusBoarding,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.StatusChangedAt == nil || !next.StatusChangedAt.Equal(at) {
+					t.Errorf("StatusChangedAt = %v, want %v", next.StatusChangedAt, at)
+				}
+				if next.ActualDeparture != nil || next.CancelledAt != nil {
+					t.Errorf("unexpected side effects: %+v", next)
+				}
+			},
+		},
+		{
+			name:    "cancelled marks flight cancelled",
+			current: flight(model.FlightStatusBoarding),
+			status:  model.FlightStatusCancelled,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.CancelledAt == nil || !next.CancelledAt.Equal(at) {
+					t.Errorf("CancelledAt = %v, want %v", next.CancelledAt, at)
+				}
+			},
+		},
+		{
+			name:    "departed sets actual departure",
+			current: flight(model.FlightStatusBoarding),
+			status:  model.FlightStatusDeparted,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.ActualDeparture == nil || !next.ActualDeparture.Equal(at) {
+					t.Errorf("ActualDeparture = %v, want %v", next.ActualDeparture, at)
+				}
+			},
+		},
+		{
+			name: "departed keeps reported actual departure",
+			current: func() *model.FlightData {
+				f := flight(model.FlightStatusBoarding)
+				f.ActualDeparture = &reported
+				return f
+			}(),
+			status: model.FlightStatusDeparted,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.ActualDeparture == nil || !next.ActualDeparture.Equal(reported) {
+					t.Errorf("ActualDeparture = %v, want %v", next.ActualDeparture, reported)
+				}
+			},
+		},
+		{
+			name:    "arrived sets actual arrival",
+			current: flight(model.FlightStatusLanded),
+			status:  model.FlightStatusArrived,
+			check: func(t *testing.T, next *model.FlightData) {
+				if next.ActualArrival == nil || !next.ActualArrival.Equal(at) {
+					t.Errorf("ActualArrival = %v, want %v", next.ActualArrival, at)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before model.FlightData
+			if tt.current != nil {
+				before = *tt.current
+			}
+
+			next, err := transitionStatus(tt.current, tt.status, at, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("transitionStatus() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("transitionStatus() error = %v", err)
+			}
+
+			if next.Status != tt.status {
+				t.Errorf("Status = %q, want %q", next.Status, tt.status)
+			}
+			if !next.UpdatedAt.Equal(now) {
+				t.Errorf("UpdatedAt = %v, want %v", next.UpdatedAt, now)
+			}
+			if tt.current.Status != before.Status || tt.current.StatusChangedAt != before.StatusChangedAt {
+				t.Errorf("current flight was modified: %+v", tt.current)
+			}
+			if tt.check != nil {
+				tt.check(t, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package flight
+
+import (
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// validateFlightRequest проверяет обязательные поля и согласованность данных рейса.
+// Для частичного обновления передается сохраненный рейс с примененной маской, см. mergedPatch.
+func validateFlightRequest(request *model.FlightRequest) error {
+	if request.FlightNumber == "" || request.DepartureDate.IsZero() {
+		return domain.Validation("missing_required_fields", "flight_number and departure_date are required")
+	}
+	if request.PassengersCount < 0 {
+		return domain.Validation("invalid_passengers_count", "passengers_count must not be negative")
+	}
+	if !request.ArrivalDate.IsZero() && request.ArrivalDate.Before(request.DepartureDate) {
+		return domain.Validation("invalid_arrival_date", "arrival_date must not be before departure_date")
+	}
+	if request.ActualDeparture != nil && request.ActualArrival != nil && request.ActualArrival.Before(*request.ActualDeparture) {
+		return domain.Validation("invalid_actual_arrival", "actual_arrival must not be before actual_departure")
+	}
+	if request.Origin != "" && request.Origin == request.Destination {
+		return domain.Validation("invalid_route", "origin and destination must differ")
+	}
+	return validateDelayCodes(request.DelayCodes)
+}
+
+// delayCodePattern числовой код задержки IATA AHM 730 из двух цифр
+var delayCodePattern = regexp.MustCompile(`^[0-9]{2}$`)
+
+// validateDelayCodes проверяет формат кодов причин задержки и отсутствие повторов
+func validateDelayCodes(codes []string) error {
+	for i, code := range codes {
+		if !delayCodePattern.MatchString(code) {
+			return domain.Validation("invalid_delay_code",
+				fmt.Sprintf("delay code %q must be a two-digit IATA AHM 730 code", code))
+		}
+		if slices.Contains(codes[:i], code) {
+			return domain.Validation("invalid_delay_code", fmt.Sprintf("delay code %q is repeated", code))
+		}
+	}
+	return nil
+}
+
+// validateUpdateMask проверяет маску частичного обновления: непустая, без повторов и неизвестных полей
+func validateUpdateMask(mask []string) error {
+	if len(mask) == 0 {
+		return domain.Validation("empty_update_mask", "at least one field must be updated")
+	}
+
+	seen := make(map[string]bool, len(mask))
+	for _, field := range mask {
+		if !slices.Contains(model.FlightPatchFields, field) {
+			return domain.Validation("invalid_update_mask",
+				fmt.Sprintf("field %q cannot be updated, allowed: %s", field, strings.Join(model.FlightPatchFields, ", ")))
+		}
+		if seen[field] {
+			return domain.Validation("invalid_update_mask", fmt.Sprintf("field %q is repeated in update_mask", field))
+		}
+		seen[field] = true
+	}
+	return nil
+}
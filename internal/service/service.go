@@ -8,8 +8,39 @@ import (
 
 type FlightService interface {
 	CreateFlight(ctx context.Context, request *model.FlightRequest) (int, error)
+	PatchFlight(ctx context.Context, patch *model.FlightPatchRequest) (int, error)
+	ChangeFlightStatus(ctx context.Context, request *model.FlightStatusRequest) (int, error)
+	CancelFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error)
+	DeleteFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error)
+	RestoreFlight(ctx context.Context, flightNumber string, departureDate time.Time) (int, error)
 	GetFlight(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightData, error)
+	GetFlightAsOf(ctx context.Context, flightNumber string, departureDate time.Time, asOf time.Time) (*model.FlightData, error)
+	GetFlightsByLocalDate(ctx context.Context, flightNumber string, date time.Time) (*model.FlightDayResponse, error)
 	GetFlightMeta(ctx context.Context, flightNumber string, status string, limit int) (*model.FlightMetaResponse, error)
+	GetFlightStatus(ctx context.Context, flightNumber string, departureDate time.Time) (*model.FlightStatusResponse, error)
+	GetFlightHistory(ctx context.Context, flightNumber string, departureDate time.Time, limit int) (*model.FlightHistoryResponse, error)
+	SearchFlights(ctx context.Context, filter model.FlightFilter) (*model.FlightSearchResponse, error)
 	ProcessFlightFromKafka(ctx context.Context, metaID int, request *model.FlightRequest) error
 	UpdateFlightMetaStatusMetrics(ctx context.Context) error
 }
+
+// WebhookService управление подписками клиента на изменения рейсов
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, request *model.WebhookRequest) (*model.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id int) (*model.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]*model.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id int, request *model.WebhookRequest) (*model.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, id int, limit int) ([]*model.WebhookDelivery, error)
+}
+
+// FlightStreamService подписка на поток обновлений рейсов (Server-Sent Events)
+type FlightStreamService interface {
+	// Subscribe возвращает канал событий и функцию отписки. Канал закрывается, если клиент
+	// не успевает читать события или сервис останавливается.
+	Subscribe(filter model.StreamFilter) (<-chan *model.StreamEvent, func(), error)
+	// Replay возвращает события после lastEventID для возобновления потока.
+	// complete равен false, если клиент отстал больше чем на stream.replay_limit событий:
+	// пропуск не досылается, клиент должен заново загрузить рейсы.
+	Replay(ctx context.Context, filter model.StreamFilter, lastEventID int64) (events []*model.StreamEvent, complete bool, err error)
+}
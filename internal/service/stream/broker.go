@@ -0,0 +1,296 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"flight-service/internal/service"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// reconnectDelay пауза перед повторной подпиской после потери соединения с Postgres
+const reconnectDelay = 2 * time.Second
+
+// Broker раздает события потока обновлений рейсов SSE клиентам этой реплики.
+// События пишутся в flight_stream_events в транзакции обработки рейса, а их ID
+// приходят всем репликам через Postgres LISTEN/NOTIFY после коммита. ID выдаются
+// в порядке коммита (см. StreamRepository.Append), поэтому события с ID меньше
+// уже разосланного не появятся и досылка после lastID или Last-Event-ID полна.
+type Broker struct {
+	pool *pgxpool.Pool
+	repo repository.StreamRepository
+	cfg  config.StreamConfig
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+	lastID      int64 // наибольший разосланный ID, с него досылаются события после переподключения
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type subscriber struct {
+	filter model.StreamFilter
+	events chan *model.StreamEvent
+}
+
+var _ service.FlightStreamService = (*Broker)(nil)
+
+func NewBroker(pool *pgxpool.Pool, repo repository.StreamRepository, cfg config.StreamConfig) *Broker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Broker{
+		pool:        pool,
+		repo:        repo,
+		cfg:         cfg,
+		subscribers: make(map[*subscriber]struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start запускает прием уведомлений и очистку журнала событий
+func (b *Broker) Start() {
+	b.wg.Add(2)
+	go b.listen()
+	go b.cleanup()
+}
+
+// Close останавливает прием уведомлений и закрывает каналы подписчиков, чтобы SSE запросы завершились
+func (b *Broker) Close() {
+	b.cancel()
+	b.wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+func (b *Broker) Subscribe(filter model.StreamFilter) (<-chan *model.StreamEvent, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, domain.Unavailable("stream_unavailable", "flight stream is shutting down", nil)
+	}
+
+	sub := &subscriber{
+		filter: filter,
+		events: make(chan *model.StreamEvent, b.cfg.BufferSize),
+	}
+	b.subscribers[sub] = struct{}{}
+	metrics.StreamSubscribers.Inc()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(sub)
+	}
+
+	return sub.events, unsubscribe, nil
+}
+
+func (b *Broker) Replay(ctx context.Context, filter model.StreamFilter, lastEventID int64) ([]*model.StreamEvent, bool, error) {
+	// Лишнее событие показывает, что пропуск не помещается в лимит
+	events, err := b.repo.ListAfter(ctx, lastEventID, filter, b.cfg.ReplayLimit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(events) > b.cfg.ReplayLimit {
+		return nil, false, nil
+	}
+	return events, true, nil
+}
+
+// remove закрывает канал подписчика, вызывается под mu
+func (b *Broker) remove(sub *subscriber) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+	metrics.StreamSubscribers.Dec()
+}
+
+func (b *Broker) dispatch(event *model.StreamEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.ID > b.lastID {
+		b.lastID = event.ID
+	}
+	metrics.StreamEventsDispatched.Inc()
+
+	for sub := range b.subscribers {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Клиент не успевает читать: отключаем его, он переподключится с Last-Event-ID
+			b.remove(sub)
+			metrics.StreamSlowSubscribers.Inc()
+		}
+	}
+}
+
+func (b *Broker) listen() {
+	defer b.wg.Done()
+
+	for {
+		err := b.listenOnce()
+		if b.ctx.Err() != nil {
+			return
+		}
+		logger.Error("Flight stream listener disconnected, reconnecting", zap.Error(err))
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) listenOnce() error {
+	conn, err := b.pool.Acquire(b.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// Соединение с LISTEN не возвращается в пул, иначе уведомления достанутся обычным запросам
+	listenConn := conn.Hijack()
+	defer listenConn.Close(context.Background())
+
+	if _, err := listenConn.Exec(b.ctx, "LISTEN "+pgx.Identifier{b.cfg.Channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", b.cfg.Channel, err)
+	}
+
+	// События, закоммиченные пока соединения не было, досылаются из журнала
+	b.catchUp()
+
+	for {
+		notification, err := listenConn.WaitForNotification(b.ctx)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			logger.Warn("Invalid flight stream notification", zap.String("payload", notification.Payload))
+			continue
+		}
+
+		b.fetchAndDispatch(id)
+	}
+}
+
+func (b *Broker) catchUp() {
+	b.mu.Lock()
+	lastID := b.lastID
+	b.mu.Unlock()
+
+	// При первом запуске досылать нечего: клиенты сами возобновляют поток по Last-Event-ID
+	if lastID == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, 5*time.Second)
+	defer cancel()
+
+	events, err := b.repo.ListAfter(ctx, lastID, model.StreamFilter{}, b.cfg.ReplayLimit+1)
+	if err != nil {
+		logger.Error("Failed to catch up flight stream events", zap.Int64("afterID", lastID), zap.Error(err))
+		return
+	}
+	if len(events) > b.cfg.ReplayLimit {
+		b.resetSubscribers(ctx, lastID)
+		return
+	}
+	for _, event := range events {
+		b.dispatch(event)
+	}
+}
+
+// resetSubscribers отключает всех подписчиков, когда пропуск после переподключения к Postgres
+// больше replay_limit. Клиенты переподключатся с Last-Event-ID и получат досылку или reset,
+// а рассылка продолжится с последнего события в журнале.
+func (b *Broker) resetSubscribers(ctx context.Context, lastID int64) {
+	logger.Warn("Flight stream gap exceeds replay limit, disconnecting subscribers",
+		zap.Int64("afterID", lastID), zap.Int("replayLimit", b.cfg.ReplayLimit))
+
+	headID, err := b.repo.LastID(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+	if err != nil {
+		// lastID не двигается: следующее переподключение снова сбросит подписчиков
+		logger.Error("Failed to load last flight stream event ID", zap.Error(err))
+		return
+	}
+	if headID > b.lastID {
+		b.lastID = headID
+	}
+}
+
+func (b *Broker) fetchAndDispatch(id int64) {
+	ctx, cancel := context.WithTimeout(b.ctx, 5*time.Second)
+	defer cancel()
+
+	event, err := b.repo.Get(ctx, id)
+	if err != nil {
+		// Событие могло быть удалено очисткой журнала, пока уведомление шло до реплики
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.Error("Failed to load flight stream event", zap.Int64("eventID", id), zap.Error(err))
+		}
+		return
+	}
+
+	b.dispatch(event)
+}
+
+// cleanup удаляет из журнала события старше retention
+func (b *Broker) cleanup() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(b.ctx, 30*time.Second)
+			deleted, err := b.repo.DeleteBefore(ctx, time.Now().Add(-b.cfg.Retention))
+			cancel()
+			if err != nil {
+				logger.Error("Failed to clean up flight stream events", zap.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				logger.Debug("Flight stream events cleaned up", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"context"
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeStreamRepository журнал событий в памяти, ID событий - 1..lastID
+type fakeStreamRepository struct {
+	repository.StreamRepository
+	lastID int64
+}
+
+func (r *fakeStreamRepository) ListAfter(_ context.Context, afterID int64, _ model.StreamFilter, limit int) ([]*model.StreamEvent, error) {
+	var events []*model.StreamEvent
+	for id := afterID + 1; id <= r.lastID && len(events) < limit; id++ {
+		events = append(events, &model.StreamEvent{ID: id, Type: "flight.updated"})
+	}
+	return events, nil
+}
+
+func (r *fakeStreamRepository) LastID(context.Context) (int64, error) {
+	return r.lastID, nil
+}
+
+func newTestBroker(repo repository.StreamRepository) *Broker {
+	logger.Init(zapcore.NewNopCore())
+	return NewBroker(nil, repo, config.StreamConfig{BufferSize: 16, ReplayLimit: 3})
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name         string
+		lastID       int64
+		lastEventID  int64
+		wantEvents   int
+		wantComplete bool
+	}{
+		{"nothing missed", 10, 10, 0, true},
+		{"gap within limit", 10, 8, 2, true},
+		{"gap equals limit", 10, 7, 3, true},
+		{"gap exceeds limit", 10, 6, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := newTestBroker(&fakeStreamRepository{lastID: tt.lastID})
+
+			events, complete, err := broker.Replay(context.Background(), model.StreamFilter{}, tt.lastEventID)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if complete != tt.wantComplete {
+				t.Errorf("Replay() complete = %v, want %v", complete, tt.wantComplete)
+			}
+			if len(events) != tt.wantEvents {
+				t.Fatalf("Replay() returned %d events, want %d", len(events), tt.wantEvents)
+			}
+			for i, event := range events {
+				if want := tt.lastEventID + int64(i) + 1; event.ID != want {
+					t.Errorf("event %d ID = %d, want %d", i, event.ID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	t.Run("gap within limit is dispatched", func(t *testing.T) {
+		broker := newTestBroker(&fakeStreamRepository{lastID: 7})
+		broker.lastID = 5
+		events, unsubscribe, err := broker.Subscribe(model.StreamFilter{})
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		defer unsubscribe()
+
+		broker.catchUp()
+
+		for _, want := range []int64{6, 7} {
+			event := <-events
+			if event.ID != want {
+				t.Errorf("dispatched event ID = %d, want %d", event.ID, want)
+			}
+		}
+		if broker.lastID != 7 {
+			t.Errorf("lastID = %d, want 7", broker.lastID)
+		}
+	})
+
+	t.Run("gap over limit disconnects subscribers", func(t *testing.T) {
+		broker := newTestBroker(&fakeStreamRepository{lastID: 20})
+		broker.lastID = 5
+		events, unsubscribe, err := broker.Subscribe(model.StreamFilter{})
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		defer unsubscribe()
+
+		broker.catchUp()
+
+		// Подписчик не должен получить часть пропуска: канал закрыт без событий
+		if event, ok := <-events; ok {
+			t.Fatalf("subscriber received event %d, want closed channel", event.ID)
+		}
+		if broker.lastID != 20 {
+			t.Errorf("lastID = %d, want 20", broker.lastID)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/metrics"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Заголовки запроса к подписчику
+const (
+	HeaderSignature = "X-Webhook-Signature" // sha256=<hex HMAC-SHA256 от "<timestamp>.<body>">
+	HeaderTimestamp = "X-Webhook-Timestamp" // unix-время подписи, защищает от повтора запроса
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// Dispatcher отправляет доставки из webhook_deliveries. Доставки создаются в транзакции
+// обработки рейса, поэтому уходят только после ее коммита.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	cfg    config.WebhooksConfig
+
+	closeChan chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewDispatcher(repo repository.WebhookRepository, cfg config.WebhooksConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:      repo,
+		client:    newHTTPClient(cfg),
+		cfg:       cfg,
+		closeChan: make(chan struct{}),
+	}
+}
+
+// Start запускает фоновую отправку
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go d.run()
+}
+
+// Close останавливает отправку и дожидается запросов в полете
+func (d *Dispatcher) Close() {
+	close(d.closeChan)
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.closeChan:
+			return
+		case <-ticker.C:
+			d.dispatchBatch()
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch() {
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.Timeout)
+	defer cancel()
+
+	// Аренда с запасом на отправку всей пачки: если реплика упадет, доставки вернутся в очередь
+	deliveries, err := d.repo.ClaimDeliveries(ctx, d.cfg.BatchSize, 2*d.cfg.Timeout)
+	if err != nil {
+		logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(delivery)
+		}()
+	}
+	wg.Wait()
+}
+
+func (d *Dispatcher) deliver(delivery *model.WebhookDelivery) {
+	status, err := d.send(delivery)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err == nil {
+		metrics.WebhookDeliveries.WithLabelValues("delivered").Inc()
+		if err := d.repo.MarkDelivered(ctx, delivery, status); err != nil {
+			logger.Error("Failed to mark webhook delivered", zap.Int64("deliveryID", delivery.ID), zap.Error(err))
+		}
+		return
+	}
+
+	var nextAttempt *time.Time
+	result := "failed"
+	if delivery.Attempts < d.cfg.MaxAttempts {
+		next := time.Now().Add(d.backoff(delivery.Attempts))
+		nextAttempt = &next
+		result = "retry"
+	}
+	metrics.WebhookDeliveries.WithLabelValues(result).Inc()
+
+	logger.Warn("Webhook delivery failed",
+		zap.Int64("deliveryID", delivery.ID),
+		zap.Int("subscriptionID", delivery.SubscriptionID),
+		zap.Int("attempt", delivery.Attempts),
+		zap.String("result", result),
+		zap.Error(err))
+
+	disabled, markErr := d.repo.MarkFailed(ctx, delivery, status, err.Error(), nextAttempt, d.cfg.DisableAfter)
+	if markErr != nil {
+		logger.Error("Failed to mark webhook delivery failed", zap.Int64("deliveryID", delivery.ID), zap.Error(markErr))
+		return
+	}
+	if disabled {
+		metrics.WebhooksDisabled.Inc()
+		logger.Warn("Webhook subscription disabled after repeated failures",
+			zap.Int("subscriptionID", delivery.SubscriptionID))
+	}
+}
+
+// send выполняет подписанный POST; успехом считается любой ответ 2xx
+func (d *Dispatcher) send(delivery *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "flight-service-webhooks")
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff экспоненциальная задержка перед попыткой attempt+1
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BackoffBase
+	for i := 1; i < attempt && delay < d.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.BackoffMax)
+}
+
+// Sign вычисляет подпись тела запроса. Получатель проверяет ее тем же секретом
+// и отклоняет запросы со старым timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
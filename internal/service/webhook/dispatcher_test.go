@@ -0,0 +1,368 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"flight-service/internal/config"
+	"flight-service/internal/logger"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap/zapcore"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// fakeWebhookRepository повторяет в памяти семантику аренды, ретраев и отключения подписки
+type fakeWebhookRepository struct {
+	mu         sync.Mutex
+	sub        model.WebhookSubscription
+	deliveries []*model.WebhookDelivery
+	retryDelay []time.Duration // задержки, назначенные MarkFailed
+}
+
+func newFakeRepository(url string, deliveries int) *fakeWebhookRepository {
+	repo := &fakeWebhookRepository{
+		sub: model.WebhookSubscription{ID: 1, URL: url, Secret: testSecret, Enabled: true},
+	}
+	for i := 1; i <= deliveries; i++ {
+		repo.deliveries = append(repo.deliveries, &model.WebhookDelivery{
+			ID:             int64(i),
+			SubscriptionID: repo.sub.ID,
+			EventType:      "flight.updated",
+			Payload:        []byte(`{"flight_number":"SU100"}`),
+			Status:         "pending",
+		})
+	}
+	return repo
+}
+
+func (r *fakeWebhookRepository) WithTx(pgx.Tx) repository.WebhookRepository { return r }
+
+func (r *fakeWebhookRepository) Create(context.Context, *model.WebhookSubscription) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeWebhookRepository) Get(context.Context, int, string) (*model.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (r *fakeWebhookRepository) List(context.Context, string) ([]*model.WebhookSubscription, error) {
+	return nil, nil
+}
+
+func (r *fakeWebhookRepository) Update(context.Context, *model.WebhookSubscription) error { return nil }
+
+func (r *fakeWebhookRepository) Delete(context.Context, int, string) error { return nil }
+
+func (r *fakeWebhookRepository) EnqueueDeliveries(context.Context, string, string, any) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeWebhookRepository) ClaimDeliveries(_ context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.sub.Enabled {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var claimed []*model.WebhookDelivery
+	for _, d := range r.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if d.Status != "pending" || d.NextAttemptAt.After(now) {
+			continue
+		}
+		d.Attempts++
+		d.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, &model.WebhookDelivery{
+			ID:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			EventType:      d.EventType,
+			Payload:        d.Payload,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			URL:            r.sub.URL,
+			Secret:         r.sub.Secret,
+		})
+	}
+	return claimed, nil
+}
+
+func (r *fakeWebhookRepository) MarkDelivered(_ context.Context, delivery *model.WebhookDelivery, responseStatus int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.find(delivery.ID)
+	d.Status = "delivered"
+	d.ResponseStatus = &responseStatus
+	d.LastError = ""
+	r.sub.ConsecutiveFailures = 0
+	return nil
+}
+
+func (r *fakeWebhookRepository) MarkFailed(_ context.Context, delivery *model.WebhookDelivery, responseStatus int, lastError string,
+	nextAttempt *time.Time, disableAfter int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.find(delivery.ID)
+	if responseStatus != 0 {
+		d.ResponseStatus = &responseStatus
+	}
+	d.LastError = lastError
+	if nextAttempt != nil {
+		r.retryDelay = append(r.retryDelay, time.Until(*nextAttempt))
+		d.NextAttemptAt = *nextAttempt
+	} else {
+		d.Status = "failed"
+	}
+
+	wasEnabled := r.sub.Enabled
+	r.sub.ConsecutiveFailures++
+	r.sub.Enabled = r.sub.Enabled && r.sub.ConsecutiveFailures < disableAfter
+	return wasEnabled && !r.sub.Enabled, nil
+}
+
+func (r *fakeWebhookRepository) ListDeliveries(context.Context, int, int) ([]*model.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *fakeWebhookRepository) find(id int64) *model.WebhookDelivery {
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			return d
+		}
+	}
+	panic("unknown delivery " + strconv.FormatInt(id, 10))
+}
+
+func (r *fakeWebhookRepository) delivery(id int64) model.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.find(id)
+}
+
+func testConfig() config.WebhooksConfig {
+	return config.WebhooksConfig{
+		Enabled:             true,
+		PollInterval:        time.Second,
+		BatchSize:           10,
+		Timeout:             2 * time.Second,
+		MaxAttempts:         5,
+		BackoffBase:         20 * time.Millisecond,
+		BackoffMax:          40 * time.Millisecond,
+		DisableAfter:        100,
+		AllowPrivateTargets: true,
+	}
+}
+
+// dispatchUntil отправляет пачки, пока не выполнится условие или не истечет время
+func dispatchUntil(t *testing.T, d *Dispatcher, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached before deadline")
+		}
+		d.dispatchBatch()
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcherSignsDelivery(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	var mu sync.Mutex
+	var got *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	repo := newFakeRepository(receiver.URL, 1)
+	d := NewDispatcher(repo, testConfig())
+	d.dispatchBatch()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got == nil {
+		t.Fatal("receiver got no request")
+	}
+	if got.Method != http.MethodPost || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("request = %s %s, want POST application/json", got.Method, got.Header.Get("Content-Type"))
+	}
+	if got.Header.Get(HeaderEvent) != "flight.updated" || got.Header.Get(HeaderDelivery) != "1" {
+		t.Errorf("event headers = %q, %q", got.Header.Get(HeaderEvent), got.Header.Get(HeaderDelivery))
+	}
+
+	timestamp, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", HeaderTimestamp, err)
+	}
+	if want := Sign(testSecret, timestamp, body); got.Header.Get(HeaderSignature) != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got.Header.Get(HeaderSignature), want)
+	}
+	if Sign("another-secret-value", timestamp, body) == got.Header.Get(HeaderSignature) {
+		t.Error("signature does not depend on the secret")
+	}
+
+	delivery := repo.delivery(1)
+	if delivery.Status != "delivered" || delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusNoContent {
+		t.Errorf("delivery = %+v, want delivered with 204", delivery)
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	var mu sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	cfg := testConfig()
+	repo := newFakeRepository(receiver.URL, 1)
+	d := NewDispatcher(repo, cfg)
+	dispatchUntil(t, d, func() bool { return repo.delivery(1).Status != "pending" })
+
+	delivery := repo.delivery(1)
+	if delivery.Status != "delivered" || delivery.Attempts != 4 {
+		t.Errorf("delivery status = %s after %d attempts, want delivered after 4", delivery.Status, delivery.Attempts)
+	}
+
+	// Задержка удваивается после каждой неудачи и ограничена backoff_max
+	want := []time.Duration{cfg.BackoffBase, 2 * cfg.BackoffBase, cfg.BackoffMax}
+	repo.mu.Lock()
+	delays := repo.retryDelay
+	repo.mu.Unlock()
+	if len(delays) != len(want) {
+		t.Fatalf("retries = %d, want %d", len(delays), len(want))
+	}
+	for i, delay := range delays {
+		if delay > want[i] || delay < want[i]-10*time.Millisecond {
+			t.Errorf("retry %d delay = %v, want about %v", i+1, delay, want[i])
+		}
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	var mu sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	cfg := testConfig()
+	cfg.MaxAttempts = 3
+	repo := newFakeRepository(receiver.URL, 1)
+	d := NewDispatcher(repo, cfg)
+	dispatchUntil(t, d, func() bool { return repo.delivery(1).Status != "pending" })
+
+	// Исчерпанная доставка больше не отправляется
+	d.dispatchBatch()
+
+	delivery := repo.delivery(1)
+	if delivery.Status != "failed" || delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("delivery = %+v, want failed with 500", delivery)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != cfg.MaxAttempts {
+		t.Errorf("requests = %d, want %d", requests, cfg.MaxAttempts)
+	}
+}
+
+func TestDispatcherDisablesFailingSubscription(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	var mu sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	cfg := testConfig()
+	cfg.BatchSize = 1
+	cfg.DisableAfter = 3
+	repo := newFakeRepository(receiver.URL, 5)
+	d := NewDispatcher(repo, cfg)
+	dispatchUntil(t, d, func() bool {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		return !repo.sub.Enabled
+	})
+
+	// Доставки отключенной подписки не отправляются
+	for i := 0; i < 5; i++ {
+		d.dispatchBatch()
+		time.Sleep(cfg.BackoffMax / 4)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != cfg.DisableAfter {
+		t.Errorf("requests = %d, want %d before the subscription is disabled", requests, cfg.DisableAfter)
+	}
+}
+
+func TestDispatcherRejectsPrivateTargets(t *testing.T) {
+	logger.Init(zapcore.NewNopCore())
+
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	cfg := testConfig()
+	cfg.AllowPrivateTargets = false
+	repo := newFakeRepository(receiver.URL, 1)
+	d := NewDispatcher(repo, cfg)
+	d.dispatchBatch()
+
+	if requests != 0 {
+		t.Errorf("receiver on loopback got %d requests", requests)
+	}
+	delivery := repo.delivery(1)
+	if delivery.Status != "pending" || !strings.Contains(delivery.LastError, errForbiddenTarget.Error()) {
+		t.Errorf("delivery = %+v, want pending retry with forbidden target error", delivery)
+	}
+}
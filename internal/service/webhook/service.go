@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flight-service/internal/auth"
+	"flight-service/internal/config"
+	"flight-service/internal/domain"
+	"flight-service/internal/model"
+	"flight-service/internal/repository"
+	"flight-service/internal/service"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+var flightPatternRe = regexp.MustCompile(`^[A-Z0-9*]{1,20}$`)
+
+const minSecretLength = 16
+
+type webhookService struct {
+	repo repository.WebhookRepository
+	cfg  config.WebhooksConfig
+}
+
+// NewWebhookService создает новый экземпляр WebhookService
+func NewWebhookService(repo repository.WebhookRepository, cfg config.WebhooksConfig) service.WebhookService {
+	return &webhookService{repo: repo, cfg: cfg}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, request *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	if err := s.validateWebhookRequest(request); err != nil {
+		return nil, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		secret = generateSecret()
+	}
+
+	sub := &model.WebhookSubscription{
+		Owner:         auth.CallerID(ctx),
+		URL:           request.URL,
+		Secret:        secret,
+		FlightNumber:  request.FlightNumber,
+		FlightPattern: request.FlightPattern,
+	}
+
+	if _, err := s.repo.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id int) (*model.WebhookSubscription, error) {
+	return s.repo.Get(ctx, id, owner(ctx))
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return s.repo.List(ctx, owner(ctx))
+}
+
+// UpdateWebhook заменяет адрес и фильтры подписки. Пустой secret оставляет прежний,
+// повторное включение сбрасывает счетчик ошибок.
+func (s *webhookService) UpdateWebhook(ctx context.Context, id int, request *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	if err := s.validateWebhookRequest(request); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.repo.Get(ctx, id, owner(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sub.URL = request.URL
+	sub.FlightNumber = request.FlightNumber
+	sub.FlightPattern = request.FlightPattern
+	if request.Secret != "" {
+		sub.Secret = request.Secret
+	}
+
+	if request.Enabled != nil && *request.Enabled != sub.Enabled {
+		sub.Enabled = *request.Enabled
+		if sub.Enabled {
+			sub.ConsecutiveFailures = 0
+			sub.DisabledAt = nil
+		} else {
+			now := time.Now()
+			sub.DisabledAt = &now
+		}
+	}
+
+	if err := s.repo.Update(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id, owner(ctx))
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, id int, limit int) ([]*model.WebhookDelivery, error) {
+	// Проверяем, что подписка принадлежит клиенту
+	if _, err := s.repo.Get(ctx, id, owner(ctx)); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, id, limit)
+}
+
+// owner ограничивает подписки клиентом запроса; администратор видит все
+func owner(ctx context.Context) string {
+	if p := auth.PrincipalFromContext(ctx); p != nil && !p.HasScope(auth.ScopeAdmin) {
+		return p.ID
+	}
+	return ""
+}
+
+func (s *webhookService) validateWebhookRequest(request *model.WebhookRequest) error {
+	u, err := url.Parse(request.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.Validation("invalid_url", "url must be an absolute http or https URL")
+	}
+	if !s.cfg.AllowPrivateTargets && validateTarget(u.Hostname()) != nil {
+		return domain.Validation("forbidden_url", "url must not point to a loopback, private or link-local address")
+	}
+	if request.FlightPattern != "" && !flightPatternRe.MatchString(request.FlightPattern) {
+		return domain.Validation("invalid_flight_pattern", "flight_pattern may contain only A-Z, 0-9 and *")
+	}
+	if request.Secret != "" && len(request.Secret) < minSecretLength {
+		return domain.Validation("invalid_secret", "secret must be at least 16 characters")
+	}
+	return nil
+}
+
+func generateSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
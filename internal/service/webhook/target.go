@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"errors"
+	"flight-service/internal/config"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errForbiddenTarget адрес подписчика во внутренней сети. Такие доставки не отправляются:
+// иначе клиент со scope webhooks мог бы обращаться через сервис к внутренним адресам
+// и читать коды ответов в /deliveries.
+var errForbiddenTarget = errors.New("webhook target address is not allowed")
+
+// sharedAddressSpace диапазон CGNAT (RFC 6598), в облаках часто используется внутри сети
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddress сообщает, можно ли отправлять доставки на адрес
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// dialControl проверяет адрес уже после разрешения имени, поэтому DNS rebinding
+// и редиректы на внутренние адреса тоже отсекаются
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !publicAddress(addr) {
+		return fmt.Errorf("%w: %s", errForbiddenTarget, host)
+	}
+	return nil
+}
+
+// newHTTPClient клиент доставок. Без allow_private_targets соединения с внутренними адресами запрещены.
+func newHTTPClient(cfg config.WebhooksConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	if !cfg.AllowPrivateTargets {
+		dialer.Control = dialControl
+		// Соединение с прокси из окружения обошло бы проверку адреса подписчика
+		transport.Proxy = nil
+	}
+
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
+
+// validateTarget отклоняет при регистрации адреса, которые заведомо внутренние.
+// Имена хостов проверяются только при отправке, после разрешения в адрес.
+func validateTarget(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errForbiddenTarget
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && !publicAddress(addr) {
+		return errForbiddenTarget
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := publicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("publicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	tests := []struct {
+		host    string
+		wantErr bool
+	}{
+		{"hooks.example.com", false},
+		{"93.184.216.34", false},
+		{"localhost", true},
+		{"LOCALHOST.", true},
+		{"api.localhost", true},
+		{"127.0.0.1", true},
+		{"169.254.169.254", true},
+		{"[::1]", true},
+		{"::1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := validateTarget(tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTarget(%q) error = %v, wantErr %v", tt.host, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errForbiddenTarget) {
+				t.Errorf("validateTarget(%q) error = %v, want errForbiddenTarget", tt.host, err)
+			}
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	if err := dialControl("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("dialControl(public) error = %v", err)
+	}
+	for _, address := range []string{"127.0.0.1:8080", "[::1]:443", "169.254.169.254:80", "10.0.0.5:443"} {
+		if err := dialControl("tcp", address, nil); !errors.Is(err, errForbiddenTarget) {
+			t.Errorf("dialControl(%s) error = %v, want errForbiddenTarget", address, err)
+		}
+	}
+}
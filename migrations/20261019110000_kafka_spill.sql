@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE kafka_spill (
+    id BIGSERIAL PRIMARY KEY,
+    meta_id INTEGER NOT NULL REFERENCES flight_meta(id) ON DELETE CASCADE,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE kafka_spill;
+-- +goose StatementEnd
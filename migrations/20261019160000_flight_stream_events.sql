@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE flight_stream_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    flight_number VARCHAR(20) NOT NULL,
+    departure_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    aircraft_type VARCHAR(50) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_flight_stream_events_created_at ON flight_stream_events (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE flight_stream_events;
+-- +goose StatementEnd
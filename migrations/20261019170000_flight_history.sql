@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE flight_history (
+    id BIGSERIAL PRIMARY KEY,
+    flight_number VARCHAR(20) NOT NULL,
+    departure_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    meta_id INTEGER NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    old_values JSONB,
+    new_values JSONB NOT NULL,
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_flight_history_flight ON flight_history (flight_number, departure_date, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE flight_history;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE flight_history
+    ADD COLUMN valid_from TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN valid_to TIMESTAMP WITH TIME ZONE;
+
+-- Версия действует до появления следующей записи по тому же рейсу
+UPDATE flight_history h
+SET valid_from = h.changed_at,
+    valid_to = v.next_from
+FROM (
+    SELECT id, LEAD(changed_at) OVER (PARTITION BY flight_number, departure_date ORDER BY id) AS next_from
+    FROM flight_history
+) v
+WHERE v.id = h.id;
+
+-- Рейсы, записанные до появления истории, получают начальную версию от updated_at
+INSERT INTO flight_history (flight_number, departure_date, meta_id, source, old_values, new_values,
+                            changed_fields, changed_at, valid_from)
+SELECT f.flight_number, f.departure_date, 0, 'migration', NULL,
+       jsonb_build_object('aircraft_type', f.aircraft_type, 'arrival_date', f.arrival_date,
+                          'passengers_count', f.passengers_count),
+       '{}', COALESCE(f.updated_at, CURRENT_TIMESTAMP), COALESCE(f.updated_at, CURRENT_TIMESTAMP)
+FROM flights f
+WHERE NOT EXISTS (
+    SELECT 1 FROM flight_history h
+    WHERE h.flight_number = f.flight_number AND h.departure_date = f.departure_date
+);
+
+ALTER TABLE flight_history ALTER COLUMN valid_from SET NOT NULL;
+
+-- У рейса не больше одной действующей версии
+CREATE UNIQUE INDEX idx_flight_history_current ON flight_history (flight_number, departure_date) WHERE valid_to IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flight_history_current;
+DELETE FROM flight_history WHERE source = 'migration';
+ALTER TABLE flight_history DROP COLUMN valid_to, DROP COLUMN valid_from;
+-- +goose StatementEnd
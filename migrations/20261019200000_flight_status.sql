@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE flights
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'boarding', 'departed', 'airborne', 'landed', 'arrived', 'cancelled', 'diverted')),
+    ADD COLUMN status_changed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE flights SET status = 'cancelled', status_changed_at = cancelled_at WHERE cancelled_at IS NOT NULL;
+
+CREATE TABLE flight_status_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    flight_number VARCHAR(20) NOT NULL,
+    departure_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    meta_id INTEGER NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_flight_status_transitions_flight ON flight_status_transitions (flight_number, departure_date, id);
+
+-- Причина, по которой сообщение отклонено при обработке
+ALTER TABLE flight_meta ADD COLUMN error TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE flight_meta DROP COLUMN error;
+DROP TABLE flight_status_transitions;
+ALTER TABLE flights DROP COLUMN status_changed_at, DROP COLUMN status;
+-- +goose StatementEnd
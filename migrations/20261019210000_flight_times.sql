@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- departure_date и arrival_date остаются временем по расписанию
+ALTER TABLE flights
+    ADD COLUMN estimated_departure TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN actual_departure TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN estimated_arrival TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN actual_arrival TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN delay_codes TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE flights
+    DROP COLUMN delay_codes,
+    DROP COLUMN actual_arrival,
+    DROP COLUMN estimated_arrival,
+    DROP COLUMN actual_departure,
+    DROP COLUMN estimated_departure;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE airports (
+    code CHAR(3) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Коды проверяются сервисом по справочнику airports; пустая строка - маршрут не указан
+ALTER TABLE flights
+    ADD COLUMN origin VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN destination VARCHAR(3) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_flights_route ON flights (origin, destination, departure_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flights_route;
+ALTER TABLE flights DROP COLUMN destination, DROP COLUMN origin;
+DROP TABLE airports;
+-- +goose StatementEnd
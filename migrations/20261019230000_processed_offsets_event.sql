@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Событие, отправленное в Kafka транзакции вместе с offset: при откате Kafka транзакции
+-- после коммита БД повторная доставка отправляет его заново
+ALTER TABLE processed_offsets
+    ADD COLUMN event_topic VARCHAR(255),
+    ADD COLUMN event_key VARCHAR(255),
+    ADD COLUMN event_payload JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE processed_offsets DROP COLUMN event_payload, DROP COLUMN event_key, DROP COLUMN event_topic;
+-- +goose StatementEnd
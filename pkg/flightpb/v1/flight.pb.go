@@ -0,0 +1,957 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: flight/v1/flight.proto
+
+package flightpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Flight struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AircraftType    string                 `protobuf:"bytes,1,opt,name=aircraft_type,json=aircraftType,proto3" json:"aircraft_type,omitempty"`
+	FlightNumber    string                 `protobuf:"bytes,2,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	ArrivalDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=arrival_date,json=arrivalDate,proto3" json:"arrival_date,omitempty"`
+	PassengersCount int32                  `protobuf:"varint,5,opt,name=passengers_count,json=passengersCount,proto3" json:"passengers_count,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// IATA коды аэропортов, пустые если маршрут не указан
+	Origin        string `protobuf:"bytes,7,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string `protobuf:"bytes,8,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Flight) Reset() {
+	*x = Flight{}
+	mi := &file_flight_v1_flight_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Flight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flight) ProtoMessage() {}
+
+func (x *Flight) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flight.ProtoReflect.Descriptor instead.
+func (*Flight) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Flight) GetAircraftType() string {
+	if x != nil {
+		return x.AircraftType
+	}
+	return ""
+}
+
+func (x *Flight) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *Flight) GetDepartureDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return nil
+}
+
+func (x *Flight) GetArrivalDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivalDate
+	}
+	return nil
+}
+
+func (x *Flight) GetPassengersCount() int32 {
+	if x != nil {
+		return x.PassengersCount
+	}
+	return 0
+}
+
+func (x *Flight) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Flight) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Flight) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type CreateFlightRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AircraftType    string                 `protobuf:"bytes,1,opt,name=aircraft_type,json=aircraftType,proto3" json:"aircraft_type,omitempty"`
+	FlightNumber    string                 `protobuf:"bytes,2,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	ArrivalDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=arrival_date,json=arrivalDate,proto3" json:"arrival_date,omitempty"`
+	PassengersCount int32                  `protobuf:"varint,5,opt,name=passengers_count,json=passengersCount,proto3" json:"passengers_count,omitempty"`
+	// IATA коды из справочника аэропортов, необязательные
+	Origin        string `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFlightRequest) Reset() {
+	*x = CreateFlightRequest{}
+	mi := &file_flight_v1_flight_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFlightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFlightRequest) ProtoMessage() {}
+
+func (x *CreateFlightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFlightRequest.ProtoReflect.Descriptor instead.
+func (*CreateFlightRequest) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateFlightRequest) GetAircraftType() string {
+	if x != nil {
+		return x.AircraftType
+	}
+	return ""
+}
+
+func (x *CreateFlightRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *CreateFlightRequest) GetDepartureDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return nil
+}
+
+func (x *CreateFlightRequest) GetArrivalDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivalDate
+	}
+	return nil
+}
+
+func (x *CreateFlightRequest) GetPassengersCount() int32 {
+	if x != nil {
+		return x.PassengersCount
+	}
+	return 0
+}
+
+func (x *CreateFlightRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *CreateFlightRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type CreateFlightResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFlightResponse) Reset() {
+	*x = CreateFlightResponse{}
+	mi := &file_flight_v1_flight_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFlightResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFlightResponse) ProtoMessage() {}
+
+func (x *CreateFlightResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFlightResponse.ProtoReflect.Descriptor instead.
+func (*CreateFlightResponse) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateFlightResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreateFlightResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetFlightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber  string                 `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlightRequest) Reset() {
+	*x = GetFlightRequest{}
+	mi := &file_flight_v1_flight_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlightRequest) ProtoMessage() {}
+
+func (x *GetFlightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlightRequest.ProtoReflect.Descriptor instead.
+func (*GetFlightRequest) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetFlightRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *GetFlightRequest) GetDepartureDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return nil
+}
+
+type GetFlightMetaRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber string                 `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	// Пустая строка - без фильтра по статусу
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// 0 - значение по умолчанию из конфига
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlightMetaRequest) Reset() {
+	*x = GetFlightMetaRequest{}
+	mi := &file_flight_v1_flight_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlightMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlightMetaRequest) ProtoMessage() {}
+
+func (x *GetFlightMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlightMetaRequest.ProtoReflect.Descriptor instead.
+func (*GetFlightMetaRequest) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetFlightMetaRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *GetFlightMetaRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetFlightMetaRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FlightMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FlightNumber  string                 `protobuf:"bytes,2,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Не заполнено, если запрос ещё не обработан
+	ProcessedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	// Идентификатор клиента, отправившего запрос
+	CreatedBy     string `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightMeta) Reset() {
+	*x = FlightMeta{}
+	mi := &file_flight_v1_flight_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightMeta) ProtoMessage() {}
+
+func (x *FlightMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightMeta.ProtoReflect.Descriptor instead.
+func (*FlightMeta) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FlightMeta) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FlightMeta) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *FlightMeta) GetDepartureDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return nil
+}
+
+func (x *FlightMeta) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FlightMeta) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FlightMeta) GetProcessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProcessedAt
+	}
+	return nil
+}
+
+func (x *FlightMeta) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_flight_v1_flight_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Pagination) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Pagination) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetFlightMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber  string                 `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	Meta          []*FlightMeta          `protobuf:"bytes,2,rep,name=meta,proto3" json:"meta,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFlightMetaResponse) Reset() {
+	*x = GetFlightMetaResponse{}
+	mi := &file_flight_v1_flight_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFlightMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlightMetaResponse) ProtoMessage() {}
+
+func (x *GetFlightMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlightMetaResponse.ProtoReflect.Descriptor instead.
+func (*GetFlightMetaResponse) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetFlightMetaResponse) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *GetFlightMetaResponse) GetMeta() []*FlightMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *GetFlightMetaResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchFlightsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустые поля не участвуют в фильтрации
+	FlightNumber  string                 `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	AircraftType  string                 `protobuf:"bytes,2,opt,name=aircraft_type,json=aircraftType,proto3" json:"aircraft_type,omitempty"`
+	DepartureFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_from,json=departureFrom,proto3" json:"departure_from,omitempty"`
+	DepartureTo   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=departure_to,json=departureTo,proto3" json:"departure_to,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Origin        string                 `protobuf:"bytes,7,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string                 `protobuf:"bytes,8,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsRequest) Reset() {
+	*x = SearchFlightsRequest{}
+	mi := &file_flight_v1_flight_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsRequest) ProtoMessage() {}
+
+func (x *SearchFlightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFlightsRequest) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchFlightsRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetAircraftType() string {
+	if x != nil {
+		return x.AircraftType
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetDepartureFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureFrom
+	}
+	return nil
+}
+
+func (x *SearchFlightsRequest) GetDepartureTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureTo
+	}
+	return nil
+}
+
+func (x *SearchFlightsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchFlightsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchFlightsRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type SearchFlightsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flights       []*Flight              `protobuf:"bytes,1,rep,name=flights,proto3" json:"flights,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsResponse) Reset() {
+	*x = SearchFlightsResponse{}
+	mi := &file_flight_v1_flight_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsResponse) ProtoMessage() {}
+
+func (x *SearchFlightsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsResponse.ProtoReflect.Descriptor instead.
+func (*SearchFlightsResponse) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchFlightsResponse) GetFlights() []*Flight {
+	if x != nil {
+		return x.Flights
+	}
+	return nil
+}
+
+func (x *SearchFlightsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type WatchFlightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber  string                 `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchFlightRequest) Reset() {
+	*x = WatchFlightRequest{}
+	mi := &file_flight_v1_flight_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchFlightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFlightRequest) ProtoMessage() {}
+
+func (x *WatchFlightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flight_v1_flight_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFlightRequest.ProtoReflect.Descriptor instead.
+func (*WatchFlightRequest) Descriptor() ([]byte, []int) {
+	return file_flight_v1_flight_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchFlightRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *WatchFlightRequest) GetDepartureDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return nil
+}
+
+var File_flight_v1_flight_proto protoreflect.FileDescriptor
+
+const file_flight_v1_flight_proto_rawDesc = "" +
+	"\n" +
+	"\x16flight/v1/flight.proto\x12\tflight.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x02\n" +
+	"\x06Flight\x12#\n" +
+	"\raircraft_type\x18\x01 \x01(\tR\faircraftType\x12#\n" +
+	"\rflight_number\x18\x02 \x01(\tR\fflightNumber\x12A\n" +
+	"\x0edeparture_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureDate\x12=\n" +
+	"\farrival_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\varrivalDate\x12)\n" +
+	"\x10passengers_count\x18\x05 \x01(\x05R\x0fpassengersCount\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06origin\x18\a \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\b \x01(\tR\vdestination\"\xc6\x02\n" +
+	"\x13CreateFlightRequest\x12#\n" +
+	"\raircraft_type\x18\x01 \x01(\tR\faircraftType\x12#\n" +
+	"\rflight_number\x18\x02 \x01(\tR\fflightNumber\x12A\n" +
+	"\x0edeparture_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureDate\x12=\n" +
+	"\farrival_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\varrivalDate\x12)\n" +
+	"\x10passengers_count\x18\x05 \x01(\x05R\x0fpassengersCount\x12\x16\n" +
+	"\x06origin\x18\x06 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\a \x01(\tR\vdestination\">\n" +
+	"\x14CreateFlightResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"z\n" +
+	"\x10GetFlightRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12A\n" +
+	"\x0edeparture_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureDate\"i\n" +
+	"\x14GetFlightMetaRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xb5\x02\n" +
+	"\n" +
+	"FlightMeta\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12#\n" +
+	"\rflight_number\x18\x02 \x01(\tR\fflightNumber\x12A\n" +
+	"\x0edeparture_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureDate\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fprocessed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\"8\n" +
+	"\n" +
+	"Pagination\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x9e\x01\n" +
+	"\x15GetFlightMetaResponse\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12)\n" +
+	"\x04meta\x18\x02 \x03(\v2\x15.flight.v1.FlightMetaR\x04meta\x125\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x15.flight.v1.PaginationR\n" +
+	"pagination\"\xca\x02\n" +
+	"\x14SearchFlightsRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12#\n" +
+	"\raircraft_type\x18\x02 \x01(\tR\faircraftType\x12A\n" +
+	"\x0edeparture_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureFrom\x12=\n" +
+	"\fdeparture_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vdepartureTo\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06origin\x18\a \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\b \x01(\tR\vdestination\"{\n" +
+	"\x15SearchFlightsResponse\x12+\n" +
+	"\aflights\x18\x01 \x03(\v2\x11.flight.v1.FlightR\aflights\x125\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x15.flight.v1.PaginationR\n" +
+	"pagination\"|\n" +
+	"\x12WatchFlightRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12A\n" +
+	"\x0edeparture_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureDate2\x88\x03\n" +
+	"\rFlightService\x12O\n" +
+	"\fCreateFlight\x12\x1e.flight.v1.CreateFlightRequest\x1a\x1f.flight.v1.CreateFlightResponse\x12;\n" +
+	"\tGetFlight\x12\x1b.flight.v1.GetFlightRequest\x1a\x11.flight.v1.Flight\x12R\n" +
+	"\rGetFlightMeta\x12\x1f.flight.v1.GetFlightMetaRequest\x1a .flight.v1.GetFlightMetaResponse\x12R\n" +
+	"\rSearchFlights\x12\x1f.flight.v1.SearchFlightsRequest\x1a .flight.v1.SearchFlightsResponse\x12A\n" +
+	"\vWatchFlight\x12\x1d.flight.v1.WatchFlightRequest\x1a\x11.flight.v1.Flight0\x01B)Z'flight-service/pkg/flightpb/v1;flightpbb\x06proto3"
+
+var (
+	file_flight_v1_flight_proto_rawDescOnce sync.Once
+	file_flight_v1_flight_proto_rawDescData []byte
+)
+
+func file_flight_v1_flight_proto_rawDescGZIP() []byte {
+	file_flight_v1_flight_proto_rawDescOnce.Do(func() {
+		file_flight_v1_flight_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flight_v1_flight_proto_rawDesc), len(file_flight_v1_flight_proto_rawDesc)))
+	})
+	return file_flight_v1_flight_proto_rawDescData
+}
+
+var file_flight_v1_flight_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_flight_v1_flight_proto_goTypes = []any{
+	(*Flight)(nil),                // 0: flight.v1.Flight
+	(*CreateFlightRequest)(nil),   // 1: flight.v1.CreateFlightRequest
+	(*CreateFlightResponse)(nil),  // 2: flight.v1.CreateFlightResponse
+	(*GetFlightRequest)(nil),      // 3: flight.v1.GetFlightRequest
+	(*GetFlightMetaRequest)(nil),  // 4: flight.v1.GetFlightMetaRequest
+	(*FlightMeta)(nil),            // 5: flight.v1.FlightMeta
+	(*Pagination)(nil),            // 6: flight.v1.Pagination
+	(*GetFlightMetaResponse)(nil), // 7: flight.v1.GetFlightMetaResponse
+	(*SearchFlightsRequest)(nil),  // 8: flight.v1.SearchFlightsRequest
+	(*SearchFlightsResponse)(nil), // 9: flight.v1.SearchFlightsResponse
+	(*WatchFlightRequest)(nil),    // 10: flight.v1.WatchFlightRequest
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_flight_v1_flight_proto_depIdxs = []int32{
+	11, // 0: flight.v1.Flight.departure_date:type_name -> google.protobuf.Timestamp
+	11, // 1: flight.v1.Flight.arrival_date:type_name -> google.protobuf.Timestamp
+	11, // 2: flight.v1.Flight.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 3: flight.v1.CreateFlightRequest.departure_date:type_name -> google.protobuf.Timestamp
+	11, // 4: flight.v1.CreateFlightRequest.arrival_date:type_name -> google.protobuf.Timestamp
+	11, // 5: flight.v1.GetFlightRequest.departure_date:type_name -> google.protobuf.Timestamp
+	11, // 6: flight.v1.FlightMeta.departure_date:type_name -> google.protobuf.Timestamp
+	11, // 7: flight.v1.FlightMeta.created_at:type_name -> google.protobuf.Timestamp
+	11, // 8: flight.v1.FlightMeta.processed_at:type_name -> google.protobuf.Timestamp
+	5,  // 9: flight.v1.GetFlightMetaResponse.meta:type_name -> flight.v1.FlightMeta
+	6,  // 10: flight.v1.GetFlightMetaResponse.pagination:type_name -> flight.v1.Pagination
+	11, // 11: flight.v1.SearchFlightsRequest.departure_from:type_name -> google.protobuf.Timestamp
+	11, // 12: flight.v1.SearchFlightsRequest.departure_to:type_name -> google.protobuf.Timestamp
+	0,  // 13: flight.v1.SearchFlightsResponse.flights:type_name -> flight.v1.Flight
+	6,  // 14: flight.v1.SearchFlightsResponse.pagination:type_name -> flight.v1.Pagination
+	11, // 15: flight.v1.WatchFlightRequest.departure_date:type_name -> google.protobuf.Timestamp
+	1,  // 16: flight.v1.FlightService.CreateFlight:input_type -> flight.v1.CreateFlightRequest
+	3,  // 17: flight.v1.FlightService.GetFlight:input_type -> flight.v1.GetFlightRequest
+	4,  // 18: flight.v1.FlightService.GetFlightMeta:input_type -> flight.v1.GetFlightMetaRequest
+	8,  // 19: flight.v1.FlightService.SearchFlights:input_type -> flight.v1.SearchFlightsRequest
+	10, // 20: flight.v1.FlightService.WatchFlight:input_type -> flight.v1.WatchFlightRequest
+	2,  // 21: flight.v1.FlightService.CreateFlight:output_type -> flight.v1.CreateFlightResponse
+	0,  // 22: flight.v1.FlightService.GetFlight:output_type -> flight.v1.Flight
+	7,  // 23: flight.v1.FlightService.GetFlightMeta:output_type -> flight.v1.GetFlightMetaResponse
+	9,  // 24: flight.v1.FlightService.SearchFlights:output_type -> flight.v1.SearchFlightsResponse
+	0,  // 25: flight.v1.FlightService.WatchFlight:output_type -> flight.v1.Flight
+	21, // [21:26] is the sub-list for method output_type
+	16, // [16:21] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_flight_v1_flight_proto_init() }
+func file_flight_v1_flight_proto_init() {
+	if File_flight_v1_flight_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flight_v1_flight_proto_rawDesc), len(file_flight_v1_flight_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flight_v1_flight_proto_goTypes,
+		DependencyIndexes: file_flight_v1_flight_proto_depIdxs,
+		MessageInfos:      file_flight_v1_flight_proto_msgTypes,
+	}.Build()
+	File_flight_v1_flight_proto = out.File
+	file_flight_v1_flight_proto_goTypes = nil
+	file_flight_v1_flight_proto_depIdxs = nil
+}
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: flight/v1/flight.proto
+
+package flightpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FlightService_CreateFlight_FullMethodName  = "/flight.v1.FlightService/CreateFlight"
+	FlightService_GetFlight_FullMethodName     = "/flight.v1.FlightService/GetFlight"
+	FlightService_GetFlightMeta_FullMethodName = "/flight.v1.FlightService/GetFlightMeta"
+	FlightService_SearchFlights_FullMethodName = "/flight.v1.FlightService/SearchFlights"
+	FlightService_WatchFlight_FullMethodName   = "/flight.v1.FlightService/WatchFlight"
+)
+
+// FlightServiceClient is the client API for FlightService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FlightService типизированный API рейсов, работающий поверх того же сервисного слоя, что и HTTP API
+type FlightServiceClient interface {
+	// CreateFlight ставит рейс в очередь на создание или обновление через Kafka
+	CreateFlight(ctx context.Context, in *CreateFlightRequest, opts ...grpc.CallOption) (*CreateFlightResponse, error)
+	// GetFlight возвращает рейс по номеру и дате вылета
+	GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*Flight, error)
+	// GetFlightMeta возвращает историю обработки запросов по рейсу
+	GetFlightMeta(ctx context.Context, in *GetFlightMetaRequest, opts ...grpc.CallOption) (*GetFlightMetaResponse, error)
+	// SearchFlights ищет рейсы по фильтрам
+	SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error)
+	// WatchFlight отправляет текущее состояние рейса и затем каждое его изменение
+	WatchFlight(ctx context.Context, in *WatchFlightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Flight], error)
+}
+
+type flightServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFlightServiceClient(cc grpc.ClientConnInterface) FlightServiceClient {
+	return &flightServiceClient{cc}
+}
+
+func (c *flightServiceClient) CreateFlight(ctx context.Context, in *CreateFlightRequest, opts ...grpc.CallOption) (*CreateFlightResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFlightResponse)
+	err := c.cc.Invoke(ctx, FlightService_CreateFlight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*Flight, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Flight)
+	err := c.cc.Invoke(ctx, FlightService_GetFlight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) GetFlightMeta(ctx context.Context, in *GetFlightMetaRequest, opts ...grpc.CallOption) (*GetFlightMetaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFlightMetaResponse)
+	err := c.cc.Invoke(ctx, FlightService_GetFlightMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchFlightsResponse)
+	err := c.cc.Invoke(ctx, FlightService_SearchFlights_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flightServiceClient) WatchFlight(ctx context.Context, in *WatchFlightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Flight], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FlightService_ServiceDesc.Streams[0], FlightService_WatchFlight_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchFlightRequest, Flight]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightService_WatchFlightClient = grpc.ServerStreamingClient[Flight]
+
+// FlightServiceServer is the server API for FlightService service.
+// All implementations must embed UnimplementedFlightServiceServer
+// for forward compatibility.
+//
+// FlightService типизированный API рейсов, работающий поверх того же сервисного слоя, что и HTTP API
+type FlightServiceServer interface {
+	// CreateFlight ставит рейс в очередь на создание или обновление через Kafka
+	CreateFlight(context.Context, *CreateFlightRequest) (*CreateFlightResponse, error)
+	// GetFlight возвращает рейс по номеру и дате вылета
+	GetFlight(context.Context, *GetFlightRequest) (*Flight, error)
+	// GetFlightMeta возвращает историю обработки запросов по рейсу
+	GetFlightMeta(context.Context, *GetFlightMetaRequest) (*GetFlightMetaResponse, error)
+	// SearchFlights ищет рейсы по фильтрам
+	SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error)
+	// WatchFlight отправляет текущее состояние рейса и затем каждое его изменение
+	WatchFlight(*WatchFlightRequest, grpc.ServerStreamingServer[Flight]) error
+	mustEmbedUnimplementedFlightServiceServer()
+}
+
+// UnimplementedFlightServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFlightServiceServer struct{}
+
+func (UnimplementedFlightServiceServer) CreateFlight(context.Context, *CreateFlightRequest) (*CreateFlightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFlight not implemented")
+}
+func (UnimplementedFlightServiceServer) GetFlight(context.Context, *GetFlightRequest) (*Flight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlight not implemented")
+}
+func (UnimplementedFlightServiceServer) GetFlightMeta(context.Context, *GetFlightMetaRequest) (*GetFlightMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlightMeta not implemented")
+}
+func (UnimplementedFlightServiceServer) SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFlights not implemented")
+}
+func (UnimplementedFlightServiceServer) WatchFlight(*WatchFlightRequest, grpc.ServerStreamingServer[Flight]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFlight not implemented")
+}
+func (UnimplementedFlightServiceServer) mustEmbedUnimplementedFlightServiceServer() {}
+func (UnimplementedFlightServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeFlightServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FlightServiceServer will
+// result in compilation errors.
+type UnsafeFlightServiceServer interface {
+	mustEmbedUnimplementedFlightServiceServer()
+}
+
+func RegisterFlightServiceServer(s grpc.ServiceRegistrar, srv FlightServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFlightServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FlightService_ServiceDesc, srv)
+}
+
+func _FlightService_CreateFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).CreateFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_CreateFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).CreateFlight(ctx, req.(*CreateFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_GetFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).GetFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_GetFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).GetFlight(ctx, req.(*GetFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_GetFlightMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlightMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).GetFlightMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_GetFlightMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).GetFlightMeta(ctx, req.(*GetFlightMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_SearchFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlightServiceServer).SearchFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlightService_SearchFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlightServiceServer).SearchFlights(ctx, req.(*SearchFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlightService_WatchFlight_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFlightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlightServiceServer).WatchFlight(m, &grpc.GenericServerStream[WatchFlightRequest, Flight]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FlightService_WatchFlightServer = grpc.ServerStreamingServer[Flight]
+
+// FlightService_ServiceDesc is the grpc.ServiceDesc for FlightService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FlightService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flight.v1.FlightService",
+	HandlerType: (*FlightServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFlight",
+			Handler:    _FlightService_CreateFlight_Handler,
+		},
+		{
+			MethodName: "GetFlight",
+			Handler:    _FlightService_GetFlight_Handler,
+		},
+		{
+			MethodName: "GetFlightMeta",
+			Handler:    _FlightService_GetFlightMeta_Handler,
+		},
+		{
+			MethodName: "SearchFlights",
+			Handler:    _FlightService_SearchFlights_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFlight",
+			Handler:       _FlightService_WatchFlight_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "flight/v1/flight.proto",
+}